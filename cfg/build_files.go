@@ -0,0 +1,176 @@
+package cfg
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// buildFileReaders infer the Java version a build needs, in order of
+// precedence within a directory. Parsing is best effort: Groovy and Kotlin
+// build scripts and POMs are scanned for the usual declarations rather than
+// evaluated, and "" is returned when none is found.
+var buildFileReaders = []struct {
+	name string
+	read func(data string) string
+}{
+	{"build.gradle.kts", readGradleBuild},
+	{"build.gradle", readGradleBuild},
+	{filepath.Join("gradle", "gradle-daemon-jvm.properties"), readGradleDaemonJVM},
+	{"pom.xml", readPom},
+}
+
+// gradleVendors maps Gradle's JvmVendorSpec names to DiscoAPI distributions.
+var gradleVendors = map[string]string{
+	"adoptium":     "temurin",
+	"adoptopenjdk": "aoj",
+	"amazon":       "corretto",
+	"azul":         "zulu",
+	"bellsoft":     "liberica",
+	"graal_vm":     "graalvm_community",
+	"ibm":          "semeru",
+	"jetbrains":    "jetbrains",
+	"microsoft":    "microsoft",
+	"oracle":       "oracle",
+	"sap":          "sap_machine",
+	"tencent":      "kona",
+}
+
+var (
+	// languageVersion = JavaLanguageVersion.of(21), languageVersion.set(JavaLanguageVersion.of("21"))
+	// or the Groovy form without the assignment.
+	gradleLanguageVersionRegexp = regexp.MustCompile(`languageVersion\s*(?:=|\.set\(|\s)\s*JavaLanguageVersion\.of\(\s*["']?(\d+)["']?\s*\)`)
+	// kotlin { jvmToolchain(21) }
+	gradleJvmToolchainRegexp = regexp.MustCompile(`jvmToolchain\(\s*(\d+)\s*\)`)
+	// vendor = JvmVendorSpec.ADOPTIUM
+	gradleVendorRegexp = regexp.MustCompile(`vendor\s*(?:=|\.set\(|\s)\s*JvmVendorSpec\.([A-Z_]+)`)
+)
+
+func readGradleBuild(data string) string {
+	data = stripGradleComments(data)
+	var version string
+	if m := gradleLanguageVersionRegexp.FindStringSubmatch(data); m != nil {
+		version = m[1]
+	} else if m := gradleJvmToolchainRegexp.FindStringSubmatch(data); m != nil {
+		version = m[1]
+	} else {
+		return ""
+	}
+	if m := gradleVendorRegexp.FindStringSubmatch(data); m != nil {
+		return gradleSelector(m[1], version)
+	}
+	return version
+}
+
+// readGradleDaemonJVM reads the toolchainVersion and toolchainVendor criteria
+// of gradle/gradle-daemon-jvm.properties.
+func readGradleDaemonJVM(data string) string {
+	var version, vendor string
+	for line := range strings.Lines(data) {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || strings.HasPrefix(strings.TrimSpace(key), "#") {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "toolchainVersion":
+			version = strings.TrimSpace(value)
+		case "toolchainVendor":
+			vendor = strings.TrimSpace(value)
+		}
+	}
+	if version == "" {
+		return ""
+	}
+	return gradleSelector(vendor, version)
+}
+
+func gradleSelector(vendor, version string) string {
+	if distribution, ok := gradleVendors[strings.ToLower(vendor)]; ok {
+		return distribution + "@" + version
+	}
+	return version
+}
+
+func stripGradleComments(data string) string {
+	var b strings.Builder
+	for line := range strings.Lines(data) {
+		if strings.HasPrefix(strings.TrimSpace(line), "//") {
+			continue
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+var (
+	xmlCommentRegexp  = regexp.MustCompile(`(?s)<!--.*?-->`)
+	pomPropertyRegexp = regexp.MustCompile(`^\$\{([^}]+)\}$`)
+	// pomLeafRegexp matches elements holding only text, such as properties.
+	pomLeafRegexp = regexp.MustCompile(`<([A-Za-z_][\w.\-]*)>\s*([^<]*?)\s*</([A-Za-z_][\w.\-]*)>`)
+)
+
+// pomVersionElements are the POM elements naming the Java version, most
+// telling first. "release" is the maven-compiler-plugin configuration.
+var pomVersionElements = []string{
+	"maven.compiler.release",
+	"release",
+	"java.version",
+	"maven.compiler.target",
+	"maven.compiler.source",
+}
+
+func readPom(data string) string {
+	elements := pomElements(xmlCommentRegexp.ReplaceAllString(data, ""))
+	for _, element := range pomVersionElements {
+		value := elements[element]
+		// Follow property references such as <release>${java.version}</release>.
+		for range 5 {
+			m := pomPropertyRegexp.FindStringSubmatch(value)
+			if m == nil {
+				break
+			}
+			value = elements[m[1]]
+		}
+		if value != "" && !strings.Contains(value, "$") {
+			return value
+		}
+	}
+	return ""
+}
+
+// pomElements returns the text of the first element of each name that holds
+// only text.
+func pomElements(data string) map[string]string {
+	elements := make(map[string]string)
+	for _, m := range pomLeafRegexp.FindAllStringSubmatch(data, -1) {
+		if _, seen := elements[m[1]]; !seen && m[1] == m[3] {
+			elements[m[1]] = m[2]
+		}
+	}
+	return elements
+}
+
+// LookupBuildJavaVersion infers the Java version from the Gradle or Maven
+// build of the current working directory, or of its parents. It returns the
+// selector and the build file it came from, or two empty strings.
+func LookupBuildJavaVersion() (selector, path string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", ""
+	}
+	return FindBuildJavaVersion(cwd, ceilingDirectories())
+}
+
+// FindBuildJavaVersion searches dir and its parents, as FindJavaVersion does,
+// for a build file declaring the Java version.
+func FindBuildJavaVersion(dir string, ceilings []string) (selector, path string) {
+	return searchUp(dir, ceilings, func(dir string) (string, string) {
+		for _, file := range buildFileReaders {
+			if selector := readVersionFile(filepath.Join(dir, file.name), file.read); selector != "" {
+				return selector, filepath.Join(dir, file.name)
+			}
+		}
+		return "", ""
+	})
+}
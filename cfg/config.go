@@ -6,20 +6,59 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/felipebz/javm/internal/state"
 )
 
 var schemaTypes = map[string]string{
-	"java.default_distribution": "string",
+	"java.default_distribution":    "string",
+	"java.libc":                    "string",
+	"java.archive_types":           "string",
+	"java.preferred_distributions": "string",
+	"java.preferred_sources":       "string",
+	"java.version_files":           "string",
+	"java.home_variables":          "string",
+	"lifecycle.policy":             "string",
+	"lifecycle.max_patches_behind": "int",
 }
 
 var defaults = map[string]any{
 	"java": map[string]any{
 		"default_distribution": "temurin",
+		"libc":                 "auto",
+		"archive_types":        "auto",
+		// "*" stands for anything not listed before it.
+		"preferred_distributions": "*",
+		"preferred_sources":       "javm,*",
+		// Pin files, most preferred first, when a directory has several.
+		"version_files": ".java-version,.sdkmanrc,.tool-versions,mise.toml,.mise.toml,.jvmrc",
+		// Versions also exported as JAVA_HOME_<major>_<ARCH>, or "installed".
+		"home_variables": "",
 	},
+	"lifecycle": map[string]any{
+		"policy":             "warn",
+		"max_patches_behind": "3",
+	},
+}
+
+// allowedValues restricts keys that only accept a fixed set of values.
+var allowedValues = map[string][]string{
+	"java.libc":              {"auto", "glibc", "musl"},
+	"java.archive_types":     {"auto"},
+	"java.preferred_sources": {"*"},
+	"lifecycle.policy":       {"off", "warn", "error"},
+}
+
+// allowedListItems restricts the items of keys holding a comma-separated list.
+// A value listed in allowedValues is accepted in place of a list.
+var allowedListItems = map[string][]string{
+	"java.archive_types":     {"tar.gz", "tgz", "tar.xz", "zip"},
+	"java.preferred_sources": {"javm", "jabba", "gradle", "intellij", "system", "*"},
+	"java.version_files":     {".java-version", ".sdkmanrc", ".tool-versions", "mise.toml", ".mise.toml", ".jvmrc"},
 }
 
 func ConfigFile() string {
@@ -28,6 +67,8 @@ func ConfigFile() string {
 
 var ErrInvalidConfigFile = errors.New("invalid config file")
 
+var ErrInvalidValue = errors.New("invalid config value")
+
 func LoadUserOverrides() (map[string]any, error) {
 	path := ConfigFile()
 	b, err := os.ReadFile(path)
@@ -94,6 +135,9 @@ func SetValue(key string, value string) error {
 	if !IsKnownKey(key) {
 		return fmt.Errorf("unknown key")
 	}
+	if err := ValidateValue(key, value); err != nil {
+		return err
+	}
 	return updateUserOverrides(func(overrides map[string]any) {
 		setByPath(overrides, strings.Split(key, "."), value)
 
@@ -143,6 +187,49 @@ func writeAtomicJSON(dst string, data any) error {
 	return state.AtomicWriteFile(dst, encoded, 0o600)
 }
 
+// ValidateValue reports whether value is acceptable for key. Keys without a
+// fixed set of values accept anything.
+func ValidateValue(key string, value string) error {
+	if schemaTypes[key] == "int" {
+		if n, err := strconv.Atoi(value); err != nil || n < 0 {
+			return fmt.Errorf("%w %q for %s: want a non-negative integer", ErrInvalidValue, value, key)
+		}
+		return nil
+	}
+	allowed, ok := allowedValues[key]
+	items, isList := allowedListItems[key]
+	if (!ok && !isList) || slices.Contains(allowed, value) {
+		return nil
+	}
+	if isList {
+		want := "a comma-separated list of " + strings.Join(items, ", ")
+		if len(allowed) > 0 {
+			want = strings.Join(allowed, ", ") + " or " + want
+		}
+		for _, item := range SplitList(value) {
+			if !slices.Contains(items, item) {
+				return fmt.Errorf("%w %q for %s: want %s", ErrInvalidValue, item, key, want)
+			}
+		}
+		if len(SplitList(value)) == 0 {
+			return fmt.Errorf("%w %q for %s: want %s", ErrInvalidValue, value, key, want)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w %q for %s: want one of %s", ErrInvalidValue, value, key, strings.Join(allowed, ", "))
+}
+
+// SplitList splits a comma-separated config value, dropping blank items.
+func SplitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func IsKnownKey(key string) bool {
 	_, ok := schemaTypes[key]
 	return ok
@@ -3,23 +3,113 @@ package cfg
 import (
 	"io/fs"
 	"os"
-	"strings"
+	"path/filepath"
 )
 
-// ReadJavaVersion reads the .java-version file from the current working directory and returns the trimmed version
-// string. If the file does not exist or cannot be read, it returns an empty string.
+// JavaVersionFile is the name of the file selecting a project's JDK.
+const JavaVersionFile = ".java-version"
+
+// ReadJavaVersion returns the trimmed selector of the nearest .java-version file, as found by LookupJavaVersion. If
+// there is none, it returns an empty string.
 func ReadJavaVersion() string {
+	selector, _ := LookupJavaVersion()
+	return selector
+}
+
+// LookupJavaVersion searches the current working directory and its parents for a file pinning the Java version, and
+// returns its selector along with the path of the file. Both are empty when no file selects a version. The files
+// looked for, and their precedence within a directory, come from java.version_files. Build files are left to
+// LookupBuildJavaVersion.
+func LookupJavaVersion() (selector, path string) {
 	cwd, err := os.Getwd()
-	if err == nil {
-		return ReadJavaVersionFromFS(os.DirFS(cwd))
+	if err != nil {
+		return "", ""
+	}
+	value, err := EffectiveValue("java.version_files")
+	if err != nil {
+		value = JavaVersionFile
+	}
+	return FindJavaVersion(cwd, SplitList(value), ceilingDirectories())
+}
+
+// FindJavaVersion searches dir and its parents for one of files, which are .java-version or the pin files of other
+// tools such as .sdkmanrc, and translates it into a javm selector. The nearest directory with a pin wins, and files
+// listed first win within a directory. The search goes up to the filesystem root, but does not enter any of the
+// ceiling directories. Files that are empty, pin no Java version or cannot be read are skipped.
+func FindJavaVersion(dir string, files []string, ceilings []string) (selector, path string) {
+	return searchUp(dir, ceilings, func(dir string) (string, string) {
+		for _, name := range files {
+			read, ok := versionFileReaders[name]
+			if !ok {
+				continue
+			}
+			if selector := readVersionFile(filepath.Join(dir, name), read); selector != "" {
+				return selector, filepath.Join(dir, name)
+			}
+		}
+		return "", ""
+	})
+}
+
+// searchUp calls find with dir and then each of its parents until it returns a selector. It stops at the filesystem
+// root, or before entering one of the ceiling directories.
+func searchUp(dir string, ceilings []string, find func(dir string) (selector, path string)) (selector, path string) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", ""
+	}
+	for {
+		if selector, path := find(dir); selector != "" {
+			return selector, path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir || isCeiling(parent, ceilings) {
+			return "", ""
+		}
+		dir = parent
+	}
+}
+
+func readVersionFile(path string, read func(data string) string) string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return read(string(b))
+}
+
+// ceilingDirectories returns the directories listed in JAVM_CEILING_DIRECTORIES, separated like PATH.
+func ceilingDirectories() []string {
+	var ceilings []string
+	for _, dir := range filepath.SplitList(os.Getenv("JAVM_CEILING_DIRECTORIES")) {
+		if dir != "" {
+			ceilings = append(ceilings, dir)
+		}
+	}
+	return ceilings
+}
+
+func isCeiling(dir string, ceilings []string) bool {
+	for _, ceiling := range ceilings {
+		abs, err := filepath.Abs(ceiling)
+		if err != nil {
+			continue
+		}
+		if abs == dir {
+			return true
+		}
+		// The working directory may have been reached through a symlink.
+		if resolved, err := filepath.EvalSymlinks(abs); err == nil && resolved == dir {
+			return true
+		}
 	}
-	return ""
+	return false
 }
 
 func ReadJavaVersionFromFS(vfs fs.FS) string {
-	b, err := fs.ReadFile(vfs, ".java-version")
+	b, err := fs.ReadFile(vfs, JavaVersionFile)
 	if err != nil {
 		return ""
 	}
-	return strings.TrimSpace(string(b))
+	return readJavaVersionFile(string(b))
 }
@@ -1,6 +1,8 @@
 package cfg
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"testing/fstest"
 )
@@ -48,3 +50,170 @@ func TestReadJavaVersionFromFS(t *testing.T) {
 		})
 	}
 }
+
+func TestFindJavaVersionWalksUp(t *testing.T) {
+	files := []string{JavaVersionFile}
+	root := t.TempDir()
+	project := filepath.Join(root, "project")
+	module := filepath.Join(project, "module-a", "src")
+	if err := os.MkdirAll(module, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	projectFile := filepath.Join(project, ".java-version")
+	if err := os.WriteFile(projectFile, []byte("temurin@21\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if selector, path := FindJavaVersion(module, files, nil); selector != "temurin@21" || path != projectFile {
+		t.Errorf("FindJavaVersion() = %q, %q, want temurin@21, %q", selector, path, projectFile)
+	}
+	if selector, path := FindJavaVersion(project, files, []string{project}); selector != "temurin@21" || path != projectFile {
+		t.Errorf("the start directory is searched even when it is a ceiling, got %q, %q", selector, path)
+	}
+	if selector, path := FindJavaVersion(module, files, []string{filepath.Join(project, "module-a")}); selector != "" || path != "" {
+		t.Errorf("search entered a ceiling directory: %q, %q", selector, path)
+	}
+
+	moduleFile := filepath.Join(project, "module-a", ".java-version")
+	if err := os.WriteFile(moduleFile, []byte("17"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if selector, path := FindJavaVersion(module, files, nil); selector != "17" || path != moduleFile {
+		t.Errorf("nearest file was not used: %q, %q", selector, path)
+	}
+}
+
+func TestLookupJavaVersionHonoursCeilingDirectories(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	root := t.TempDir()
+	project := filepath.Join(root, "project")
+	if err := os.MkdirAll(filepath.Join(project, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".java-version"), []byte("21"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(filepath.Join(project, "sub"))
+
+	t.Setenv("JAVM_CEILING_DIRECTORIES", "")
+	if selector, _ := LookupJavaVersion(); selector != "21" {
+		t.Errorf("LookupJavaVersion() = %q, want 21", selector)
+	}
+	t.Setenv("JAVM_CEILING_DIRECTORIES", "/nonexistent"+string(os.PathListSeparator)+root)
+	if selector, path := LookupJavaVersion(); selector != "" || path != "" {
+		t.Errorf("LookupJavaVersion() = %q, %q past JAVM_CEILING_DIRECTORIES", selector, path)
+	}
+}
+
+func TestVersionFileReaders(t *testing.T) {
+	tests := []struct {
+		file string
+		data string
+		want string
+	}{
+		{".java-version", "temurin@21\n", "temurin@21"},
+		{".java-version", "openjdk64-17.0.9\n", "17.0.9"},
+		{".java-version", "temurin64-21.0.2", "temurin@21.0.2"},
+		{".java-version", "previous-lts", "previous-lts"},
+		{".jvmrc", "zulu@17\n", "zulu@17"},
+		{".sdkmanrc", "# Enable auto-env\njava=21.0.2-tem\n", "temurin@21.0.2"},
+		{".sdkmanrc", "java=17.0.9-amzn\nmaven=3.9.6\n", "corretto@17.0.9"},
+		{".sdkmanrc", "java=21.0.2.fx-zulu", "zulu@21.0.2"},
+		{".sdkmanrc", "maven=3.9.6\n", ""},
+		{".tool-versions", "nodejs 20.11.0\njava temurin-21.0.2+13.0.LTS\n", "temurin@21.0.2+13"},
+		{".tool-versions", "java zulu-17.46.19 system\n", "zulu@dist:17.46.19"},
+		{".tool-versions", "java corretto-17.0.9.8.1", "corretto@dist:17.0.9.8.1"},
+		{".tool-versions", "java semeru-openj9-17.0.9+9_openj9-0.41.0", "semeru@17.0.9+9"},
+		{".tool-versions", "java openjdk-21", "oracle_open_jdk@21"},
+		{".tool-versions", "ruby 3.3.0\n", ""},
+		{"mise.toml", "[env]\njava = \"ignored\"\n[tools]\njava = \"zulu-17\" # LTS\n", "zulu@17"},
+		{"mise.toml", "[tools]\njava = [\"graalvm-community-21.0.2\", \"temurin-17\"]\n", "graalvm_community@21.0.2"},
+		{".mise.toml", "[tools]\n\"java\" = { version = \"temurin-21\", os = [\"linux\"] }\n", "temurin@21"},
+		{"mise.toml", "tools.java = \"21\"\n", "21"},
+		{"mise.toml", "[tools]\nnode = \"20\"\n", ""},
+	}
+	for _, tt := range tests {
+		if got := versionFileReaders[tt.file](tt.data); got != tt.want {
+			t.Errorf("%s %q: got %q, want %q", tt.file, tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestFindJavaVersionPrecedence(t *testing.T) {
+	project := t.TempDir()
+	sub := filepath.Join(project, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string]string{
+		".sdkmanrc":      "java=21.0.2-tem\n",
+		".tool-versions": "java zulu-17\n",
+	} {
+		if err := os.WriteFile(filepath.Join(project, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(sub, ".tool-versions"), []byte("nodejs 20\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []string{".tool-versions", ".sdkmanrc"}
+	if selector, path := FindJavaVersion(sub, files, nil); selector != "zulu@17" || path != filepath.Join(project, ".tool-versions") {
+		t.Errorf("FindJavaVersion() = %q, %q, want the .tool-versions pin", selector, path)
+	}
+	files = []string{".sdkmanrc", ".tool-versions"}
+	if selector, _ := FindJavaVersion(sub, files, nil); selector != "temurin@21.0.2" {
+		t.Errorf("FindJavaVersion() = %q, want the .sdkmanrc pin", selector)
+	}
+	if selector, _ := FindJavaVersion(sub, []string{JavaVersionFile}, nil); selector != "" {
+		t.Errorf("FindJavaVersion() read a file that is not listed: %q", selector)
+	}
+}
+
+func TestBuildFileReaders(t *testing.T) {
+	tests := []struct {
+		name string
+		read func(string) string
+		data string
+		want string
+	}{
+		{"kotlin toolchain", readGradleBuild, "java {\n    toolchain {\n        languageVersion = JavaLanguageVersion.of(21)\n    }\n}\n", "21"},
+		{"kotlin toolchain set", readGradleBuild, "java.toolchain.languageVersion.set(JavaLanguageVersion.of(\"17\"))", "17"},
+		{"groovy toolchain", readGradleBuild, "java {\n  toolchain {\n    languageVersion JavaLanguageVersion.of(11)\n    vendor = JvmVendorSpec.AZUL\n  }\n}\n", "zulu@11"},
+		{"kotlin jvmToolchain", readGradleBuild, "kotlin {\n    jvmToolchain(17)\n}\n", "17"},
+		{"commented out", readGradleBuild, "// languageVersion = JavaLanguageVersion.of(8)\n", ""},
+		{"daemon jvm", readGradleDaemonJVM, "#This file is generated by updateDaemonJvm\ntoolchainVersion=21\ntoolchainVendor=ADOPTIUM\n", "temurin@21"},
+		{"daemon jvm without vendor", readGradleDaemonJVM, "toolchainVersion=17\n", "17"},
+		{"pom release property", readPom, "<project><properties><maven.compiler.release>17</maven.compiler.release></properties></project>", "17"},
+		{"pom plugin release", readPom, "<project><properties><jdk>21</jdk></properties><build><plugins><plugin><configuration><release>${jdk}</release></configuration></plugin></plugins></build></project>", "21"},
+		{"pom spring boot", readPom, "<project><properties>\n  <java.version>17</java.version>\n</properties></project>", "17"},
+		{"pom legacy target", readPom, "<project><!-- <maven.compiler.release>21</maven.compiler.release> --><properties><maven.compiler.target>1.8</maven.compiler.target></properties></project>", "1.8"},
+		{"pom unresolved property", readPom, "<project><properties><maven.compiler.release>${missing}</maven.compiler.release></properties></project>", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.read(tt.data); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLookupJavaVersionIgnoresBuildFiles(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	t.Setenv("JAVM_CEILING_DIRECTORIES", "")
+	project := t.TempDir()
+	module := filepath.Join(project, "module")
+	if err := os.Mkdir(module, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	pom := filepath.Join(project, "pom.xml")
+	if err := os.WriteFile(pom, []byte("<project><properties><maven.compiler.release>17</maven.compiler.release></properties></project>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(module)
+	if selector, path := LookupJavaVersion(); selector != "" {
+		t.Errorf("LookupJavaVersion() = %q, %q, want no pin", selector, path)
+	}
+	if selector, path := LookupBuildJavaVersion(); selector != "17" || path != pom {
+		t.Errorf("LookupBuildJavaVersion() = %q, %q, want 17 from %q", selector, path, pom)
+	}
+}
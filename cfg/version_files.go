@@ -0,0 +1,225 @@
+package cfg
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versionFileReaders extract a javm selector from the pin files javm reads,
+// translating the identifiers of other tools. They return "" when the file
+// pins no Java version.
+var versionFileReaders = map[string]func(data string) string{
+	".java-version":  readJavaVersionFile,
+	".jvmrc":         readJavaVersionFile,
+	".sdkmanrc":      readSdkmanrc,
+	".tool-versions": readToolVersions,
+	"mise.toml":      readMiseToml,
+	".mise.toml":     readMiseToml,
+}
+
+// toolDistributions maps the vendor names used by asdf, mise and jenv to
+// DiscoAPI distributions. Names not listed are used as they are.
+var toolDistributions = map[string]string{
+	"adoptopenjdk":        "aoj",
+	"adoptopenjdk-openj9": "aoj_openj9",
+	"graalvm-community":   "graalvm_community",
+	"oracle-graalvm":      "graalvm",
+	"openjdk":             "oracle_open_jdk",
+	"sapmachine":          "sap_machine",
+	"semeru-openj9":       "semeru",
+	"liberica-nik":        "liberica_native",
+	"zulu-prime":          "zulu_prime",
+}
+
+// sdkmanDistributions maps the vendor suffixes of SDKMAN identifiers, as in
+// "21.0.2-tem", to DiscoAPI distributions.
+var sdkmanDistributions = map[string]string{
+	"tem":        "temurin",
+	"zulu":       "zulu",
+	"amzn":       "corretto",
+	"librca":     "liberica",
+	"nik":        "liberica_native",
+	"graal":      "graalvm",
+	"graalce":    "graalvm_community",
+	"ms":         "microsoft",
+	"oracle":     "oracle",
+	"open":       "oracle_open_jdk",
+	"sapmchn":    "sap_machine",
+	"sem":        "semeru",
+	"kona":       "kona",
+	"albba":      "dragonwell",
+	"dragonwell": "dragonwell",
+	"jbr":        "jetbrains",
+	"mandrel":    "mandrel",
+	"bisheng":    "bisheng",
+	"trava":      "trava",
+}
+
+// toolVersionRegexp matches the part of a tool's version identifier that javm
+// understands, dropping suffixes such as ".LTS" in "21.0.2+13.0.LTS" or ".fx"
+// in "21.0.2.fx".
+var toolVersionRegexp = regexp.MustCompile(`^\d+(?:\.\d+)*(?:_\d+)?(?:\+\d+)?`)
+
+// jenvNameRegexp matches jenv names such as "openjdk64-17.0.9".
+var jenvNameRegexp = regexp.MustCompile(`^([A-Za-z][A-Za-z_.]*?)(?:64)?-(\d.*)$`)
+
+// readJavaVersionFile reads a file holding a javm selector, also accepting
+// the names jenv writes to .java-version.
+func readJavaVersionFile(data string) string {
+	selector := strings.TrimSpace(data)
+	m := jenvNameRegexp.FindStringSubmatch(selector)
+	if m == nil || strings.Contains(selector, "@") {
+		return selector
+	}
+	// jenv names JDKs after the vendor `java -version` reports, which is
+	// "openjdk" for every OpenJDK build rather than a distribution.
+	vendor := strings.ToLower(m[1])
+	if vendor == "openjdk" || vendor == "java" {
+		return toolSelector("", m[2])
+	}
+	if distribution, ok := toolDistributions[vendor]; ok {
+		vendor = distribution
+	}
+	return toolSelector(vendor, m[2])
+}
+
+// readSdkmanrc reads the "java=21.0.2-tem" line of an .sdkmanrc file.
+func readSdkmanrc(data string) string {
+	for line := range strings.Lines(data) {
+		key, value, ok := strings.Cut(stripComment(line), "=")
+		if !ok || strings.TrimSpace(key) != "java" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		version, vendor, ok := cutLast(value, "-")
+		if !ok {
+			return toolSelector("", value)
+		}
+		if distribution, ok := sdkmanDistributions[vendor]; ok {
+			vendor = distribution
+		}
+		return toolSelector(vendor, version)
+	}
+	return ""
+}
+
+// readToolVersions reads the "java temurin-21.0.2+13.0.LTS" line of an asdf
+// .tool-versions file. Fallback versions after the first are ignored.
+func readToolVersions(data string) string {
+	for line := range strings.Lines(data) {
+		fields := strings.Fields(stripComment(line))
+		if len(fields) < 2 || fields[0] != "java" {
+			continue
+		}
+		return toolIdentifierSelector(fields[1])
+	}
+	return ""
+}
+
+// readMiseToml reads the java entry of the [tools] table of a mise
+// configuration, which may be a string, an array of versions or a table with
+// a version key.
+func readMiseToml(data string) string {
+	var inTools bool
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if strings.HasPrefix(line, "[") {
+			inTools = line == "[tools]"
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if inTools {
+			if unquoted, err := strconv.Unquote(key); err == nil {
+				key = unquoted
+			}
+		} else if key == "tools.java" {
+			key = "java"
+		} else {
+			continue
+		}
+		if key != "java" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(value, "["):
+			value, _, _ = strings.Cut(strings.TrimPrefix(value, "["), ",")
+		case strings.HasPrefix(value, "{"):
+			_, after, found := strings.Cut(value, "version")
+			if !found {
+				return ""
+			}
+			_, value, _ = strings.Cut(after, "=")
+			value, _, _ = strings.Cut(value, ",")
+			value = strings.TrimSuffix(strings.TrimSpace(value), "}")
+		}
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		return toolIdentifierSelector(value)
+	}
+	return ""
+}
+
+// toolIdentifierSelector translates "vendor-version" identifiers, as used by
+// asdf and mise, into javm selectors. Identifiers without a vendor, such as
+// "21" or "latest", are used as they are.
+func toolIdentifierSelector(identifier string) string {
+	for i := 0; i < len(identifier)-1; i++ {
+		if identifier[i] == '-' && identifier[i+1] >= '0' && identifier[i+1] <= '9' {
+			vendor := strings.ToLower(identifier[:i])
+			if distribution, ok := toolDistributions[vendor]; ok {
+				vendor = distribution
+			}
+			return toolSelector(vendor, identifier[i+1:])
+		}
+	}
+	return identifier
+}
+
+// toolSelector builds the selector of a distribution's version. Versions that
+// cannot be Java versions, such as zulu's 21.32.17 or corretto's 17.0.9.8.1,
+// are the distribution's own and select by "dist:".
+func toolSelector(distribution, version string) string {
+	if v := toolVersionRegexp.FindString(version); v != "" {
+		version = v
+	}
+	if distribution == "" {
+		return version
+	}
+	if isDistributionVersion(version) {
+		release, _, _ := strings.Cut(version, "+")
+		return distribution + "@dist:" + release
+	}
+	return distribution + "@" + version
+}
+
+func isDistributionVersion(version string) bool {
+	release, _, _ := strings.Cut(version, "+")
+	numbers := strings.Split(release, ".")
+	if len(numbers) > 4 {
+		return true
+	}
+	major, err := strconv.Atoi(numbers[0])
+	return err == nil && major >= 9 && len(numbers) > 1 && numbers[1] != "0"
+}
+
+func stripComment(line string) string {
+	line, _, _ = strings.Cut(line, "#")
+	return line
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
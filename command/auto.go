@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/felipebz/javm/cfg"
+)
+
+// The shell hooks installed by `javm init --auto` remember which selector they
+// switched to, and which JDK was active before, in these variables.
+const (
+	autoVersionEnv  = "JAVM_AUTO_VERSION"
+	autoPreviousEnv = "JAVM_AUTO_PREVIOUS"
+)
+
+// autoUse switches to the JDK selected by the .java-version file nearest to
+// the current directory. Nothing is discovered while the selector is the one
+// already in use, so running it on every directory change stays cheap. When
+// leaving a project the JDK active before entering it is restored, falling
+// back to the default version. Problems are only logged, as they must not
+// break the prompt.
+func autoUse(ctx context.Context) []string {
+	selector, _ := cfg.LookupJavaVersion()
+	current, active := os.LookupEnv(autoVersionEnv)
+	if selector == current {
+		return nil
+	}
+	if selector == "" {
+		out, err := autoRestore(ctx, os.Getenv(autoPreviousEnv))
+		if err != nil {
+			loggerFromContext(ctx).Warn(err)
+			out = nil
+		}
+		return append(out, "UNSET\t"+autoVersionEnv, "UNSET\t"+autoPreviousEnv)
+	}
+
+	out, err := UseContext(ctx, selector)
+	if err != nil {
+		// Remember the selector anyway, so the warning is not repeated
+		// until the directory selects something else.
+		loggerFromContext(ctx).Warn(err)
+	}
+	out = append(out, "SET\t"+autoVersionEnv+"\t"+selector)
+	if !active {
+		out = append(out, "SET\t"+autoPreviousEnv+"\t"+activeJDKPath())
+	}
+	return out
+}
+
+// autoRestore activates previous, the JDK in use before entering a project,
+// or the default version when javm had not activated one.
+func autoRestore(ctx context.Context, previous string) ([]string, error) {
+	if previous != "" {
+		return usePath(previous)
+	}
+	selector, err := readDefaultVersion()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			loggerFromContext(ctx).Warn(err)
+		}
+		return deactivate()
+	}
+	out, err := UseContext(ctx, selector)
+	if err != nil {
+		loggerFromContext(ctx).Warn(err)
+		return deactivate()
+	}
+	return out, nil
+}
+
+// activeJDKPath returns the path of the JDK javm activated in this shell, or
+// "" when JAVA_HOME was not set by javm.
+func activeJDKPath() string {
+	if _, ok := os.LookupEnv("JAVA_HOME_BEFORE_JAVM"); !ok {
+		return ""
+	}
+	javaHome := os.Getenv("JAVA_HOME")
+	if runtime.GOOS == "darwin" {
+		// usePath adds Contents/Home again.
+		javaHome = strings.TrimSuffix(javaHome, string(filepath.Separator)+filepath.Join("Contents", "Home"))
+	}
+	return javaHome
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/felipebz/javm/internal/state"
+)
+
+// loadJSONCache decodes the cache file at path into v. It reports false when
+// the file does not exist yet.
+func loadJSONCache(path string, v any) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("read cache: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("parse cache %s: %w", path, err)
+	}
+	return true, nil
+}
+
+func saveJSONCache(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cache: %w", err)
+	}
+	return state.WithFileLock(path, func() error {
+		if err := state.AtomicWriteFile(path, data, 0o600); err != nil {
+			return fmt.Errorf("write cache: %w", err)
+		}
+		return nil
+	})
+}
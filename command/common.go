@@ -2,18 +2,21 @@ package command
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"sort"
 	"strings"
 
+	"github.com/felipebz/javm/cfg"
 	"github.com/felipebz/javm/discoapi"
+	"github.com/felipebz/javm/discovery"
 	"github.com/felipebz/javm/semver"
 )
 
 type PackagesClient interface {
-	GetPackagesContext(ctx context.Context, os, arch, distribution, version string) ([]discoapi.Package, error)
+	GetPackagesContext(ctx context.Context, query discoapi.PackageQuery) ([]discoapi.Package, error)
 }
 
 type PackagesWithInfoClient interface {
@@ -21,13 +24,28 @@ type PackagesWithInfoClient interface {
 	GetPackageInfoContext(ctx context.Context, id string) (*discoapi.PackageInfo, error)
 }
 
+// CatalogClient lists packages along with the distributions naming them, so
+// that selectors can use distribution synonyms.
+type CatalogClient interface {
+	PackagesClient
+	DistributionsClient
+}
+
+// InstallClient is what `install` needs from DiscoAPI, including the major
+// versions that keep lifecycle warnings current.
+type InstallClient interface {
+	PackagesWithInfoClient
+	DistributionsClient
+	MajorVersionsClient
+}
+
 type packageIndex struct {
 	ByVersion map[*semver.Version]discoapi.Package
 	Sorted    []*semver.Version
 }
 
-func makePackageIndex(ctx context.Context, client PackagesClient, osFlag, archFlag, distributionFlag string) (*packageIndex, error) {
-	pkgs, err := client.GetPackagesContext(ctx, osFlag, archFlag, distributionFlag, "")
+func makePackageIndex(ctx context.Context, client PackagesClient, query discoapi.PackageQuery) (*packageIndex, error) {
+	pkgs, err := client.GetPackagesContext(ctx, query)
 	if err != nil {
 		return nil, NetworkError(err)
 	}
@@ -39,7 +57,7 @@ func packageIndexFromPackages(pkgs []discoapi.Package) *packageIndex {
 	var sorted []*semver.Version
 
 	for _, pkg := range pkgs {
-		v, err := semver.ParseVersion(fmt.Sprintf("%s@%s", pkg.Distribution, stripBuildSuffix(pkg.JavaVersion)))
+		v, err := semver.ParseVersion(fmt.Sprintf("%s@%s", pkg.Distribution, pkg.JavaVersion))
 		if err == nil {
 			byVersion[v] = pkg
 			sorted = append(sorted, v)
@@ -49,11 +67,77 @@ func packageIndexFromPackages(pkgs []discoapi.Package) *packageIndex {
 	return &packageIndex{ByVersion: byVersion, Sorted: sorted}
 }
 
-func stripBuildSuffix(javaVersion string) string {
-	if before, _, ok := strings.Cut(javaVersion, "+"); ok {
-		return before
+// remoteReleases lists the feature releases in index, marked as LTS when
+// DiscoAPI reports them as such.
+func remoteReleases(index *packageIndex) []semver.MajorRelease {
+	releases := make([]semver.MajorRelease, 0, len(index.Sorted))
+	for _, v := range index.Sorted {
+		releases = append(releases, semver.MajorRelease{Major: v.Major(), LTS: index.ByVersion[v].IsLTS()})
+	}
+	return releases
+}
+
+// localReleases lists the feature releases of the installed JDKs selected by
+// rng's distribution. JDKs without an LTS marker in their release file fall
+// back to the LTS cadence.
+func localReleases(jdks []discovery.JDK, rng *semver.Range) []semver.MajorRelease {
+	var releases []semver.MajorRelease
+	for _, jdk := range jdks {
+		v, err := semver.ParseVersion(jdk.Identifier)
+		if err != nil {
+			v, err = semver.ParseVersion(jdk.Version)
+		}
+		if err != nil || !rng.MatchesQualifier(v) {
+			continue
+		}
+		releases = append(releases, semver.MajorRelease{Major: v.Major(), LTS: jdk.LTS || isLTSFeatureRelease(v.Major())})
+	}
+	return releases
+}
+
+// isLTSFeatureRelease applies the LTS cadence of 8, 11 and then every fourth
+// release from 17.
+func isLTSFeatureRelease(feature uint64) bool {
+	return feature == 8 || feature == 11 || (feature >= 17 && (feature-17)%4 == 0)
+}
+
+// resolveRange resolves symbolic selectors such as "lts" against releases.
+func resolveRange(ctx context.Context, rng *semver.Range, releases []semver.MajorRelease) (*semver.Range, error) {
+	if rng.Symbol() == "" {
+		return rng, nil
+	}
+	resolved, err := rng.Resolve(releases)
+	if err != nil {
+		return nil, NotFoundError(err)
+	}
+	loggerFromContext(ctx).Debugf("%s selects %s", rng, resolved)
+	return resolved, nil
+}
+
+// libcFlagValue returns the configured C library used as the --libc default.
+func libcFlagValue() string {
+	libc, err := cfg.EffectiveValue("java.libc")
+	if err != nil || libc == "" {
+		return discoapi.LibCAuto
+	}
+	return libc
+}
+
+// archiveTypes returns the configured archive type preference, or nil to use
+// the platform default.
+func archiveTypes() []string {
+	value, err := cfg.EffectiveValue("java.archive_types")
+	if err != nil || value == "auto" {
+		return nil
 	}
-	return javaVersion
+	return cfg.SplitList(value)
+}
+
+func validateLibC(libc string) error {
+	if err := cfg.ValidateValue("java.libc", libc); err != nil {
+		return UsageError(fmt.Errorf("invalid value for --libc: %w", err))
+	}
+	return nil
 }
 
 func parseTrimTo(value string) semver.VersionPart {
@@ -71,7 +155,11 @@ func parseTrimTo(value string) semver.VersionPart {
 
 func printForShellToEval(out []string, fd3 string) error {
 	if fd3 != "" {
-		if err := os.WriteFile(fd3, []byte(strings.Join(out, "\n")), 0600); err != nil {
+		var b strings.Builder
+		if err := renderEnvironment(&b, "fd3", out); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fd3, []byte(b.String()), 0600); err != nil {
 			return fmt.Errorf("write fd3 %q: %w", fd3, err)
 		}
 		return nil
@@ -85,10 +173,8 @@ func printForShellToEval(out []string, fd3 string) error {
 }
 
 func writeShellEnvironment(w io.Writer, out []string) error {
-	for _, line := range out {
-		if _, err := fmt.Fprintln(w, line); err != nil {
-			return shellIntegrationUnavailable()
-		}
+	if err := renderEnvironment(w, "fd3", out); err != nil {
+		return shellIntegrationUnavailable()
 	}
 	return nil
 }
@@ -103,3 +189,39 @@ func shellIntegrationError(hint shellIntegrationHint, ok bool) error {
 	}
 	return fmt.Errorf("%w; run `javm init <shell>` and invoke javm through the generated shell wrapper", ErrShellIntegration)
 }
+
+// projectJavaVersion returns the selector of the nearest .java-version file,
+// logging which file it came from.
+func projectJavaVersion(ctx context.Context) string {
+	selector, path := cfg.LookupJavaVersion()
+	if selector != "" {
+		loggerFromContext(ctx).Info("Using ", selector, " from ", path)
+	}
+	return selector
+}
+
+// projectOrBuildJavaVersion is projectJavaVersion, falling back to the version
+// the Gradle or Maven build declares when nothing is pinned.
+func projectOrBuildJavaVersion(ctx context.Context) string {
+	if selector := projectJavaVersion(ctx); selector != "" {
+		return selector
+	}
+	selector, path := cfg.LookupBuildJavaVersion()
+	if selector != "" {
+		loggerFromContext(ctx).Info("Using ", selector, " inferred from ", path)
+	}
+	return selector
+}
+
+// implicitSelector returns the version to use when none is given: the one of
+// the nearest .java-version file, or else the default version.
+func implicitSelector(ctx context.Context) (string, error) {
+	if selector := projectJavaVersion(ctx); selector != "" {
+		return selector, nil
+	}
+	selector, err := readDefaultVersion()
+	if errors.Is(err, os.ErrNotExist) {
+		return "", UsageError(errors.New("no version given, and neither .java-version nor a default version selects one"))
+	}
+	return selector, err
+}
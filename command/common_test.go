@@ -3,10 +3,14 @@ package command
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/felipebz/javm/discoapi"
+	"github.com/felipebz/javm/discovery"
+	"github.com/felipebz/javm/semver"
 )
 
 type failingShellWriter struct{}
@@ -63,7 +67,7 @@ func TestMakePackageIndex(t *testing.T) {
 			{JavaVersion: "17+35", Distribution: "zulu", DistributionVersion: "17"},
 		},
 	}
-	idx, err := makePackageIndex(context.Background(), mock, "linux", "amd64", "")
+	idx, err := makePackageIndex(context.Background(), mock, discoapi.PackageQuery{OS: "linux", Arch: "amd64"})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -81,6 +85,52 @@ func TestMakePackageIndex(t *testing.T) {
 	}
 }
 
+func TestPackageIndexKeepsBuildsApart(t *testing.T) {
+	idx := packageIndexFromPackages([]discoapi.Package{
+		{Id: "b13", JavaVersion: "21.0.2+13", Distribution: "temurin"},
+		{Id: "b12", JavaVersion: "21.0.2+12", Distribution: "temurin"},
+		{Id: "legacy", JavaVersion: "1.8.0_392-b08", Distribution: "zulu"},
+	})
+	if len(idx.Sorted) != 3 {
+		t.Fatalf("expected 3 versions, got %v", idx.Sorted)
+	}
+	rng, err := semver.ParseRange("temurin@21.0.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := newestInRange(idx, rng); v == nil || idx.ByVersion[v].Id != "b13" {
+		t.Fatalf("newestInRange() = %v, want the newest build", v)
+	}
+	rng, err = semver.ParseRange("temurin@<21.0.2+13")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := newestInRange(idx, rng); v == nil || idx.ByVersion[v].Id != "b12" {
+		t.Fatalf("newestInRange() = %v, want the older build", v)
+	}
+}
+
+func TestIsInstalledAs(t *testing.T) {
+	ver, err := semver.ParseVersion("temurin@21.0.2+13")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		jdk  discovery.JDK
+		want bool
+	}{
+		{discovery.JDK{Identifier: "temurin@21.0.2+13"}, true},
+		{discovery.JDK{Identifier: "temurin@21.0.2"}, true},
+		{discovery.JDK{Identifier: "temurin@21.0.2+12"}, false},
+		{discovery.JDK{Identifier: "zulu@21.0.2"}, false},
+	}
+	for _, tt := range tests {
+		if got := isInstalledAs(tt.jdk, ver); got != tt.want {
+			t.Errorf("isInstalledAs(%s) = %v, want %v", tt.jdk.Identifier, got, tt.want)
+		}
+	}
+}
+
 func hasPackageWithVersion(idx *packageIndex, distribution, version string) bool {
 	for _, pkg := range idx.ByVersion {
 		if pkg.Distribution == distribution && strings.HasPrefix(pkg.JavaVersion, version) {
@@ -89,3 +139,32 @@ func hasPackageWithVersion(idx *packageIndex, distribution, version string) bool
 	}
 	return false
 }
+
+func TestProjectOrBuildJavaVersion(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	t.Setenv("JAVM_CEILING_DIRECTORIES", "")
+	project := t.TempDir()
+	module := filepath.Join(project, "module")
+	if err := os.Mkdir(module, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(module, "build.gradle"), []byte("java { toolchain { languageVersion = JavaLanguageVersion.of(17) } }\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(module)
+	ctx := context.Background()
+	if got := projectJavaVersion(ctx); got != "" {
+		t.Errorf("projectJavaVersion() = %q, want no pin", got)
+	}
+	if got := projectOrBuildJavaVersion(ctx); got != "17" {
+		t.Errorf("projectOrBuildJavaVersion() = %q, want 17 from the build", got)
+	}
+
+	// An explicit pin wins over the build, even further up.
+	if err := os.WriteFile(filepath.Join(project, ".java-version"), []byte("21\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := projectOrBuildJavaVersion(ctx); got != "21" {
+		t.Errorf("projectOrBuildJavaVersion() = %q, want the pin", got)
+	}
+}
@@ -72,6 +72,9 @@ func runSetConfig(cmd *cobra.Command, args []string) error {
 		return UsageError(fmt.Errorf("unknown key %q", key))
 	}
 	if err := cfg.SetValue(key, val); err != nil {
+		if errors.Is(err, cfg.ErrInvalidValue) {
+			return UsageError(err)
+		}
 		return fmt.Errorf("failed to write config: %w", configError(err))
 	}
 	return nil
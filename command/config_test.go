@@ -2,6 +2,7 @@ package command
 
 import (
 	"bytes"
+	"errors"
 	"strings"
 	"testing"
 )
@@ -46,3 +47,36 @@ func TestConfigUsesCobraStreams(t *testing.T) {
 		}
 	})
 }
+
+func TestConfigSetRejectsValuesOutsideTheAllowedSet(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	cmd := NewConfigCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"set", "java.libc", "uclibc"})
+
+	err := cmd.Execute()
+	if !errors.Is(err, ErrUsage) || !strings.Contains(err.Error(), "auto, glibc, musl") {
+		t.Fatalf("expected usage error listing allowed values, got %v", err)
+	}
+}
+
+func TestConfigSetValidatesArchiveTypeLists(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	for _, value := range []string{"zip, tar.xz", "auto"} {
+		cmd := NewConfigCommand()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs([]string{"set", "java.archive_types", value})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("set %q: unexpected error: %v", value, err)
+		}
+	}
+
+	cmd := NewConfigCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"set", "java.archive_types", "zip,7z"})
+	if err := cmd.Execute(); !errors.Is(err, ErrUsage) || !strings.Contains(err.Error(), `"7z"`) {
+		t.Fatalf("expected usage error naming 7z, got %v", err)
+	}
+}
@@ -3,9 +3,8 @@ package command
 import (
 	"os"
 	"path/filepath"
-	"regexp"
+	"strings"
 
-	"github.com/felipebz/javm/cfg"
 	"github.com/spf13/cobra"
 )
 
@@ -29,18 +28,39 @@ func NewDeactivateCommand() *cobra.Command {
 }
 
 func deactivate() ([]string, error) {
-	sep := string(os.PathListSeparator)
-	pth, _ := os.LookupEnv("PATH")
-	rgxp := regexp.MustCompile(regexp.QuoteMeta(filepath.Join(cfg.Dir(), "jdk")) + "[^" + sep + "]+[" + sep + "]")
 	// strip references to managed jdks dir, otherwise leave unchanged
-	pth = rgxp.ReplaceAllString(pth, "")
+	prefix := managedJDKPathPrefix()
+	out := []string{"PATH_REMOVE_PREFIX\t" + prefix}
 	javaHome, overrideWasSet := os.LookupEnv("JAVA_HOME_BEFORE_JAVM")
-	if !overrideWasSet {
+	if overrideWasSet {
+		// The JDK javm activated may live outside the managed directory.
+		if active := os.Getenv("JAVA_HOME"); active != "" && active != javaHome && !strings.HasPrefix(active, prefix) {
+			out = append(out, "PATH_REMOVE\t"+filepath.Join(active, "bin"))
+		}
+		// `use` took the bin directory of every JDK outside javm off PATH, so
+		// the one from before javm has to be put back.
+		if javaHome != "" {
+			home := javaHome
+			if !strings.HasSuffix(filepath.Clean(home), filepath.Join("Contents", "Home")) {
+				var err error
+				if home, err = jdkHome(home); err != nil {
+					return nil, err
+				}
+			}
+			out = append(out, "PATH_PREPEND\t"+filepath.Join(home, "bin"))
+		}
+	} else {
 		javaHome, _ = os.LookupEnv("JAVA_HOME")
 	}
-	return []string{
-		"SET\tPATH\t" + pth,
-		"SET\tJAVA_HOME\t" + javaHome,
+	out = append(out,
+		"SET\tJAVA_HOME\t"+javaHome,
 		"UNSET\tJAVA_HOME_BEFORE_JAVM",
-	}, nil
+	)
+	for _, name := range exportedJavaHomes() {
+		out = append(out, "UNSET\t"+name)
+	}
+	if _, ok := os.LookupEnv(javaHomesVariable); ok {
+		out = append(out, "UNSET\t"+javaHomesVariable)
+	}
+	return out, nil
 }
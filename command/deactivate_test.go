@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/felipebz/javm/cfg"
@@ -22,14 +23,67 @@ func TestDeactivate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
+	systemJavaHome, err := jdkHome("/system-jdk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	systemJavaBin := filepath.Join(systemJavaHome, "bin")
 	expected := []string{
-		"SET\tPATH\t" + "/usr/local/bin" + sep + "/system-jdk/bin" + sep + "/usr/bin",
+		"PATH_REMOVE_PREFIX\t" + filepath.Join(cfg.Dir(), "jdk") + string(os.PathSeparator),
+		"PATH_PREPEND\t" + systemJavaBin,
 		"SET\tJAVA_HOME\t" + "/system-jdk",
 		"UNSET\tJAVA_HOME_BEFORE_JAVM",
 	}
 	if !reflect.DeepEqual(actual, expected) {
 		t.Fatalf("actual: %v != expected: %v", actual, expected)
 	}
+	want := systemJavaBin + sep + "/usr/local/bin" + sep + "/system-jdk/bin" + sep + "/usr/bin"
+	if systemJavaBin == "/system-jdk/bin" {
+		// Prepending moves the entry to the front.
+		want = systemJavaBin + sep + "/usr/local/bin" + sep + "/usr/bin"
+	}
+	if path := resolvePathOps(parseEnvChanges(actual), os.Getenv("PATH"))[0].value; path != want {
+		t.Fatalf("PATH after deactivate: %s", path)
+	}
+}
+
+func TestDeactivateRemovesUnmanagedJDK(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	t.Setenv("PATH", "/opt/jdk-21/bin"+sep+"/usr/bin")
+	t.Setenv("JAVA_HOME", "/opt/jdk-21")
+	t.Setenv("JAVA_HOME_BEFORE_JAVM", "")
+	actual, err := deactivate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(actual, "PATH_REMOVE\t"+filepath.Join("/opt/jdk-21", "bin")) {
+		t.Fatalf("the JDK javm activated stays on PATH: %v", actual)
+	}
+}
+
+// `use` removes the bin directories of JDKs outside javm from PATH, so the JDK
+// from before javm gets its bin directory back.
+func TestDeactivateRestoresPreviousJDKOnPath(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	javaHome := filepath.Join(cfg.Dir(), "jdk", "temurin@21.0.2+13")
+	t.Setenv("PATH", filepath.Join(javaHome, "bin")+sep+"/usr/bin")
+	t.Setenv("JAVA_HOME", javaHome)
+	t.Setenv("JAVA_HOME_BEFORE_JAVM", "/usr/lib/jvm/java-17")
+	actual, err := deactivate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous, err := jdkHome("/usr/lib/jvm/java-17")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin := filepath.Join(previous, "bin")
+	if !slices.Contains(actual, "PATH_PREPEND\t"+bin) {
+		t.Fatalf("the previous JDK was not put back on PATH: %v", actual)
+	}
+	if path := resolvePathOps(parseEnvChanges(actual), os.Getenv("PATH"))[0].value; path != bin+sep+"/usr/bin" {
+		t.Fatalf("PATH after deactivate: %s", path)
+	}
 }
 
 func TestDeactivateInUnusedEnv(t *testing.T) {
@@ -43,7 +97,7 @@ func TestDeactivateInUnusedEnv(t *testing.T) {
 		t.Fatalf("err: %v", err)
 	}
 	expected := []string{
-		"SET\tPATH\t" + "/usr/local/bin:/system-jdk/bin:/usr/bin",
+		"PATH_REMOVE_PREFIX\t" + filepath.Join(cfg.Dir(), "jdk") + string(os.PathSeparator),
 		"SET\tJAVA_HOME\t" + "/system-jdk",
 		"UNSET\tJAVA_HOME_BEFORE_JAVM",
 	}
@@ -0,0 +1,216 @@
+package command
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/discoapi"
+	"github.com/felipebz/javm/semver"
+)
+
+// distributionCache keeps the DiscoAPI distribution list, which is needed to
+// map synonyms such as "adoptium" to the canonical "temurin" offline.
+type distributionCache struct {
+	Fetched       time.Time               `json:"fetched"`
+	Distributions []discoapi.Distribution `json:"distributions"`
+}
+
+const distributionCacheTTL = 24 * time.Hour
+
+func distributionCacheFile() string {
+	return filepath.Join(cfg.Dir(), "distributions.json")
+}
+
+func saveDistributionCache(distributions []discoapi.Distribution) error {
+	return saveJSONCache(distributionCacheFile(), distributionCache{Fetched: time.Now().UTC(), Distributions: distributions})
+}
+
+// knownDistributions returns the cached distributions, refreshing a missing or
+// stale cache unless client is nil. It returns nil when the distributions are
+// unknown.
+func knownDistributions(ctx context.Context, client DistributionsClient) []discoapi.Distribution {
+	var cache distributionCache
+	found, err := loadJSONCache(distributionCacheFile(), &cache)
+	if err != nil {
+		loggerFromContext(ctx).Debug("Ignoring distribution cache: ", err)
+	}
+	if found && time.Since(cache.Fetched) < distributionCacheTTL {
+		return cache.Distributions
+	}
+	if client == nil {
+		return cache.Distributions
+	}
+	distributions, err := client.GetDistributionsContext(ctx)
+	if err != nil {
+		loggerFromContext(ctx).Debug("Could not refresh distributions: ", err)
+		return cache.Distributions
+	}
+	if err := saveDistributionCache(distributions); err != nil {
+		loggerFromContext(ctx).Debug("Could not cache distributions: ", err)
+	}
+	return distributions
+}
+
+// canonicalDistribution returns the api_parameter of the distribution name
+// refers to, matching synonyms and ignoring case and separators.
+func canonicalDistribution(name string, distributions []discoapi.Distribution) (string, bool) {
+	key := distributionKey(name)
+	for _, d := range distributions {
+		if distributionKey(d.APIParameter) == key || distributionKey(d.Name) == key {
+			return d.APIParameter, true
+		}
+	}
+	for _, d := range distributions {
+		if slices.ContainsFunc(d.Synonyms, func(synonym string) bool { return distributionKey(synonym) == key }) {
+			return d.APIParameter, true
+		}
+	}
+	return "", false
+}
+
+// canonicalizeDistribution maps name to its canonical distribution. Unknown
+// names are rejected with suggestions, unless no distribution list is
+// available, in which case name is passed through.
+func canonicalizeDistribution(ctx context.Context, name string, distributions []discoapi.Distribution) (string, error) {
+	if name == "" || name == "*" || len(distributions) == 0 {
+		return name, nil
+	}
+	if canonical, ok := canonicalDistribution(name, distributions); ok {
+		if canonical != name {
+			loggerFromContext(ctx).Debugf("Distribution %s is known as %s", name, canonical)
+		}
+		return canonical, nil
+	}
+	err := fmt.Errorf("unknown distribution %q", name)
+	if suggestions := distributionSuggestions(name, distributions); len(suggestions) > 0 {
+		err = fmt.Errorf("%w; did you mean %s?", err, strings.Join(suggestions, ", "))
+	}
+	return "", UsageError(err)
+}
+
+// mapQualifiers returns rng with every distribution it names replaced by
+// canonical(name). Glob patterns are left as they are.
+func mapQualifiers(rng *semver.Range, canonical func(name string) (string, error)) (*semver.Range, error) {
+	qualifiers := rng.Qualifiers()
+	for i, q := range qualifiers {
+		if semver.IsQualifierPattern(q) {
+			continue
+		}
+		mapped, err := canonical(q)
+		if err != nil {
+			return nil, err
+		}
+		qualifiers[i] = mapped
+	}
+	if joined := strings.Join(qualifiers, "|"); joined != rng.Qualifier {
+		return rng.WithQualifier(joined), nil
+	}
+	return rng, nil
+}
+
+// installDistributions lists the distributions to search for rng, most
+// preferred first. Glob patterns such as "graalvm*" are expanded against
+// distributions, in alphabetical order.
+func installDistributions(rng *semver.Range, distributions []discoapi.Distribution) ([]string, error) {
+	var names []string
+	for _, q := range rng.Qualifiers() {
+		expanded := []string{q}
+		if q != "*" && semver.IsQualifierPattern(q) {
+			if len(distributions) == 0 {
+				return nil, UsageError(fmt.Errorf("cannot expand %q without the list of distributions", q))
+			}
+			expanded = nil
+			for _, d := range distributions {
+				if semver.MatchesQualifierPattern(q, d.APIParameter) {
+					expanded = append(expanded, d.APIParameter)
+				}
+			}
+			if len(expanded) == 0 {
+				return nil, UsageError(fmt.Errorf("no distribution matches %q", q))
+			}
+			slices.Sort(expanded)
+		}
+		for _, name := range expanded {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// distributionSuggestions lists up to three distributions whose name or a
+// synonym is close to name, closest first.
+func distributionSuggestions(name string, distributions []discoapi.Distribution) []string {
+	key := distributionKey(name)
+	type candidate struct {
+		name     string
+		distance int
+	}
+	var candidates []candidate
+	for _, d := range distributions {
+		best := -1
+		for _, alias := range append([]string{d.APIParameter, d.Name}, d.Synonyms...) {
+			aliasKey := distributionKey(alias)
+			distance := editDistance(key, aliasKey)
+			if len(key) >= 3 && (strings.HasPrefix(aliasKey, key) || strings.HasPrefix(key, aliasKey)) {
+				distance = min(distance, 1)
+			}
+			if best < 0 || distance < best {
+				best = distance
+			}
+		}
+		if best <= max(2, len(key)/4) {
+			candidates = append(candidates, candidate{d.APIParameter, best})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return cmp.Or(cmp.Compare(a.distance, b.distance), strings.Compare(a.name, b.name))
+	})
+	var names []string
+	for _, c := range candidates {
+		if !slices.Contains(names, c.name) {
+			names = append(names, c.name)
+		}
+	}
+	return names[:min(len(names), 3)]
+}
+
+// distributionKey folds case and drops separators, so "Oracle OpenJDK",
+// "oracle-openjdk" and "oracle_open_jdk" compare equal.
+func distributionKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', ' ', '.':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
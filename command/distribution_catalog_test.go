@@ -0,0 +1,176 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/felipebz/javm/discoapi"
+	"github.com/felipebz/javm/discovery"
+	"github.com/felipebz/javm/semver"
+)
+
+var catalogTestDistributions = []discoapi.Distribution{
+	{Name: "Temurin", APIParameter: "temurin", Synonyms: []string{"temurin", "adoptium", "adoptopenjdk"}},
+	{Name: "Oracle OpenJDK", APIParameter: "oracle_open_jdk", Synonyms: []string{"oracle_open_jdk", "oracle_openjdk"}},
+	{Name: "GraalVM CE 21", APIParameter: "graalvm_ce21", Synonyms: []string{"graalvm_ce21", "graalvmce21"}},
+	{Name: "GraalVM CE 17", APIParameter: "graalvm_ce17", Synonyms: []string{"graalvm_ce17"}},
+	{Name: "Zulu", APIParameter: "zulu"},
+}
+
+func TestCanonicalDistribution(t *testing.T) {
+	tests := map[string]string{
+		"temurin":        "temurin",
+		"AdoptOpenJDK":   "temurin",
+		"oracle-openjdk": "oracle_open_jdk",
+		"Oracle OpenJDK": "oracle_open_jdk",
+		"graalvm-ce21":   "graalvm_ce21",
+	}
+	for name, want := range tests {
+		if got, ok := canonicalDistribution(name, catalogTestDistributions); !ok || got != want {
+			t.Errorf("canonicalDistribution(%q) = %q, %v; want %q", name, got, ok, want)
+		}
+	}
+	if got, ok := canonicalDistribution("corretto", catalogTestDistributions); ok {
+		t.Errorf("canonicalDistribution(corretto) = %q, want no match", got)
+	}
+}
+
+func TestCanonicalizeDistributionSuggestsCloseMatches(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"graalvm-ce", "did you mean graalvm_ce17, graalvm_ce21?"},
+		{"temurn", "did you mean temurin?"},
+		{"zuul", "did you mean zulu?"},
+		{"microsoft", `unknown distribution "microsoft"`},
+	}
+	for _, tt := range tests {
+		_, err := canonicalizeDistribution(context.Background(), tt.name, catalogTestDistributions)
+		if !errors.Is(err, ErrUsage) || !strings.HasSuffix(err.Error(), tt.want) {
+			t.Errorf("canonicalizeDistribution(%q) = %v, want a usage error ending in %q", tt.name, err, tt.want)
+		}
+	}
+	if got, err := canonicalizeDistribution(context.Background(), "anything", nil); err != nil || got != "anything" {
+		t.Errorf("without a distribution list, names must pass through: %q, %v", got, err)
+	}
+}
+
+type distributionsPackagesClient struct {
+	queryRecordingClient
+}
+
+func (c *distributionsPackagesClient) GetDistributionsContext(context.Context) ([]discoapi.Distribution, error) {
+	return catalogTestDistributions, nil
+}
+
+func TestRunInstallMapsDistributionSynonyms(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	client := &distributionsPackagesClient{queryRecordingClient{packages: func(discoapi.PackageQuery) []discoapi.Package {
+		return []discoapi.Package{{Id: "jdk", Distribution: "temurin", JavaVersion: "21.0.4+7"}}
+	}}}
+	if _, err := runInstall(context.Background(), client, "adoptium@21", "", hostInstallTarget()); !errors.Is(err, ErrNetwork) {
+		t.Fatalf("expected the package info error, got %v", err)
+	}
+	if got := client.queries[0].Distribution; got != "temurin" {
+		t.Fatalf("queried distribution %q, want temurin", got)
+	}
+
+	// The fetched list is cached, so local lookups understand synonyms too.
+	jdks := []discovery.JDK{{Identifier: "temurin@21.0.4", Version: "21.0.4", Source: "javm"}}
+	if jdk, err := FindBestMatchJDK(jdks, "adoptopenjdk@21"); err != nil || jdk.Identifier != "temurin@21.0.4" {
+		t.Fatalf("FindBestMatchJDK(adoptopenjdk@21) = %v, %v", jdk.Identifier, err)
+	}
+}
+
+func TestInstallDistributionsExpandsPatternsInOrder(t *testing.T) {
+	rng, err := semver.ParseRange("zulu|graalvm_ce*|temurin|zulu@21")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := installDistributions(rng, catalogTestDistributions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"zulu", "graalvm_ce17", "graalvm_ce21", "temurin"}; !slices.Equal(got, want) {
+		t.Fatalf("installDistributions() = %v, want %v", got, want)
+	}
+	for _, selector := range []string{"corretto*@21", "graalvm*@21"} {
+		rng, err := semver.ParseRange(selector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		distributions := catalogTestDistributions
+		if selector == "graalvm*@21" {
+			distributions = nil
+		}
+		if _, err := installDistributions(rng, distributions); !errors.Is(err, ErrUsage) {
+			t.Errorf("installDistributions(%s) error = %v, want a usage error", selector, err)
+		}
+	}
+}
+
+func TestRunInstallTriesDistributionsInOrder(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	var infoID string
+	client := &distributionsPackagesClient{queryRecordingClient{packages: func(query discoapi.PackageQuery) []discoapi.Package {
+		switch query.Distribution {
+		case "zulu":
+			return []discoapi.Package{{Id: "zulu", Distribution: "zulu", JavaVersion: "21.0.4+7", TermOfSupport: "lts"}}
+		case "temurin":
+			return []discoapi.Package{{Id: "temurin", Distribution: "temurin", JavaVersion: "21.0.3+9"}}
+		}
+		return nil
+	}}}
+	resolving := &packageInfoRecorder{queryRecordingClient: &client.queryRecordingClient, id: &infoID}
+	tests := map[string]string{
+		"adoptium|zulu@21":        "temurin",
+		"zulu|temurin@21":         "zulu",
+		"graalvm*|temurin@21":     "temurin",
+		"graalvm*|zulu@lts":       "zulu",
+		"oracle_open_jdk|zulu@21": "zulu",
+	}
+	// Warm the distribution cache so that patterns can be expanded.
+	knownDistributions(context.Background(), client)
+	for selector, want := range tests {
+		infoID = ""
+		if _, err := runInstall(context.Background(), resolving, selector, "", hostInstallTarget()); !errors.Is(err, ErrNetwork) {
+			t.Fatalf("%s: expected the package info error, got %v", selector, err)
+		}
+		if infoID != want {
+			t.Errorf("%s installed package %q, want %q", selector, infoID, want)
+		}
+	}
+}
+
+func TestFindBestMatchJDKPrefersDistributionsInOrder(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	jdks := []discovery.JDK{
+		{Identifier: "zulu@21.0.4", Version: "21.0.4", Source: "javm"},
+		{Identifier: "temurin@21.0.2", Version: "21.0.2", Source: "javm"},
+		{Identifier: "graalvm_ce21@21.0.1", Version: "21.0.1", Source: "javm"},
+		{Identifier: "corretto@21.0.5", Version: "21.0.5", Source: "system"},
+	}
+	tests := map[string]string{
+		"temurin|zulu@21":          "temurin@21.0.2",
+		"zulu|temurin@21":          "zulu@21.0.4",
+		"corretto|zulu@21":         "corretto@21.0.5",
+		"liberica|graalvm*@21":     "graalvm_ce21@21.0.1",
+		"liberica|temurin|zulu@17": "",
+	}
+	for selector, want := range tests {
+		got, err := FindBestMatchJDK(jdks, selector)
+		if want == "" {
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("FindBestMatchJDK(%s) error = %v, want not found", selector, err)
+			}
+			continue
+		}
+		if err != nil || got.Identifier != want {
+			t.Errorf("FindBestMatchJDK(%s) = %q, %v; want %q", selector, got.Identifier, err, want)
+		}
+	}
+}
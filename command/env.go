@@ -0,0 +1,322 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// envChange is one environment change, as computed by usePath and deactivate
+// in the lines read by the shell wrappers: "SET\tKEY\tVALUE", "UNSET\tKEY",
+// and the PATH operations "PATH_PREPEND\tDIR", "PATH_REMOVE\tDIR" and
+// "PATH_REMOVE_PREFIX\tPREFIX", which the shell applies to its current PATH.
+type envChange struct {
+	op    string
+	key   string
+	value string
+}
+
+func parseEnvChanges(out []string) []envChange {
+	var changes []envChange
+	for _, line := range out {
+		parts := strings.SplitN(line, "\t", 3)
+		switch {
+		case len(parts) == 3 && parts[0] == "SET":
+			changes = append(changes, envChange{op: parts[0], key: parts[1], value: parts[2]})
+		case len(parts) == 2 && parts[0] == "UNSET":
+			changes = append(changes, envChange{op: parts[0], key: parts[1]})
+		case len(parts) == 2 && isPathOp(parts[0]):
+			changes = append(changes, envChange{op: parts[0], key: "PATH", value: parts[1]})
+		}
+	}
+	return changes
+}
+
+func isPathOp(op string) bool {
+	return op == "PATH_PREPEND" || op == "PATH_REMOVE" || op == "PATH_REMOVE_PREFIX"
+}
+
+// resolvePathOps replaces the PATH operations of changes by setting PATH to
+// the result of applying them to path, for formats that cannot express them.
+func resolvePathOps(changes []envChange, path string) []envChange {
+	var resolved []envChange
+	at := -1
+	for _, c := range changes {
+		if !isPathOp(c.op) {
+			resolved = append(resolved, c)
+			continue
+		}
+		path = applyPathOp(path, c)
+		if at < 0 {
+			at = len(resolved)
+			resolved = append(resolved, envChange{})
+		}
+	}
+	if at >= 0 {
+		resolved[at] = envChange{op: "SET", key: "PATH", value: path}
+	}
+	return resolved
+}
+
+// applyPathOp applies a PATH operation to the list of directories path.
+func applyPathOp(path string, c envChange) string {
+	var kept []string
+	for _, entry := range filepath.SplitList(path) {
+		switch c.op {
+		case "PATH_PREPEND", "PATH_REMOVE":
+			if samePath(entry, c.value) {
+				continue
+			}
+		case "PATH_REMOVE_PREFIX":
+			if len(entry) >= len(c.value) && samePath(entry[:len(c.value)], c.value) {
+				continue
+			}
+		}
+		kept = append(kept, entry)
+	}
+	if c.op == "PATH_PREPEND" {
+		kept = append([]string{c.value}, kept...)
+	}
+	return strings.Join(kept, string(os.PathListSeparator))
+}
+
+// samePath compares paths the way the file system does, ignoring case on
+// Windows.
+func samePath(a, b string) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// envRenderers write environment changes for a shell or tool. "fd3" is the
+// format the shell wrappers generated by `javm init` read.
+var envRenderers = map[string]func(w io.Writer, changes []envChange) error{
+	"fd3":    renderFD3,
+	"sh":     renderSh,
+	"fish":   renderFish,
+	"pwsh":   renderPwsh,
+	"cmd":    renderCmd,
+	"nu":     renderNu,
+	"dotenv": renderDotenv,
+	"json":   renderJSON,
+	"github": renderGitHub,
+}
+
+func renderEnvironment(w io.Writer, format string, out []string) error {
+	if err := validateEnvFormat(format); err != nil {
+		return err
+	}
+	changes := parseEnvChanges(out)
+	if format != "fd3" {
+		changes = resolvePathOps(changes, os.Getenv("PATH"))
+	}
+	return envRenderers[format](w, changes)
+}
+
+func validateEnvFormat(format string) error {
+	if _, ok := envRenderers[format]; !ok {
+		return UsageError(fmt.Errorf("unsupported format: %s\nSupported formats: %s", format, strings.Join(envFormats(), ", ")))
+	}
+	return nil
+}
+
+// envFormats returns the formats of `javm env`, leaving out the internal one.
+func envFormats() []string {
+	var formats []string
+	for format := range envRenderers {
+		if format != "fd3" {
+			formats = append(formats, format)
+		}
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// renderLines writes one line per change, as returned by line.
+func renderLines(w io.Writer, changes []envChange, line func(c envChange) string) error {
+	for _, c := range changes {
+		if _, err := fmt.Fprintln(w, line(c)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderFD3(w io.Writer, changes []envChange) error {
+	return renderLines(w, changes, func(c envChange) string {
+		switch {
+		case c.op == "UNSET":
+			return "UNSET\t" + c.key
+		case isPathOp(c.op):
+			return c.op + "\t" + c.value
+		}
+		return "SET\t" + c.key + "\t" + c.value
+	})
+}
+
+func renderSh(w io.Writer, changes []envChange) error {
+	return renderLines(w, changes, func(c envChange) string {
+		if c.op == "UNSET" {
+			return "unset " + c.key
+		}
+		return "export " + c.key + "='" + strings.ReplaceAll(c.value, "'", `'\''`) + "'"
+	})
+}
+
+func renderFish(w io.Writer, changes []envChange) error {
+	return renderLines(w, changes, func(c envChange) string {
+		if c.op == "UNSET" {
+			return "set -e " + c.key
+		}
+		value := "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(c.value) + "'"
+		if c.key == "PATH" {
+			// fish keeps PATH as a list.
+			return "set -gx PATH (string split -- " + string(os.PathListSeparator) + " " + value + ")"
+		}
+		return "set -gx " + c.key + " " + value
+	})
+}
+
+func renderPwsh(w io.Writer, changes []envChange) error {
+	return renderLines(w, changes, func(c envChange) string {
+		if c.op == "UNSET" {
+			return "Remove-Item -ErrorAction SilentlyContinue Env:" + c.key
+		}
+		return "$env:" + c.key + " = '" + strings.ReplaceAll(c.value, "'", "''") + "'"
+	})
+}
+
+func renderCmd(w io.Writer, changes []envChange) error {
+	return renderLines(w, changes, func(c envChange) string {
+		if c.op == "UNSET" {
+			return `set "` + c.key + `="`
+		}
+		return `set "` + c.key + "=" + escapeBatchValue(c.value) + `"`
+	})
+}
+
+func renderNu(w io.Writer, changes []envChange) error {
+	return renderLines(w, changes, func(c envChange) string {
+		if c.op == "UNSET" {
+			return "hide-env " + c.key
+		}
+		return "$env." + c.key + ` = "` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(c.value) + `"`
+	})
+}
+
+func renderDotenv(w io.Writer, changes []envChange) error {
+	return renderLines(w, changes, func(c envChange) string {
+		if c.op == "UNSET" {
+			return c.key + "="
+		}
+		if strings.ContainsAny(c.value, " \t\"'#$\\") {
+			return c.key + `="` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`).Replace(c.value) + `"`
+		}
+		return c.key + "=" + c.value
+	})
+}
+
+// renderJSON writes an object of the variables, with null for unset ones.
+func renderJSON(w io.Writer, changes []envChange) error {
+	env := make(map[string]*string, len(changes))
+	for _, c := range changes {
+		if c.op == "UNSET" {
+			env[c.key] = nil
+		} else {
+			env[c.key] = &c.value
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(env)
+}
+
+// renderGitHub appends the variables to $GITHUB_ENV and the JDK's bin
+// directory to $GITHUB_PATH, which GitHub Actions apply to the following
+// steps. PATH itself is left to $GITHUB_PATH. $GITHUB_ENV cannot unset
+// variables, and javm's own bookkeeping is of no use to later steps, so both
+// are left out.
+func renderGitHub(w io.Writer, changes []envChange) error {
+	envFile, pathFile := os.Getenv("GITHUB_ENV"), os.Getenv("GITHUB_PATH")
+	if envFile == "" || pathFile == "" {
+		return UsageError(errors.New("the github format needs GITHUB_ENV and GITHUB_PATH, which GitHub Actions set"))
+	}
+	var env, path strings.Builder
+	for _, c := range changes {
+		switch {
+		case c.key == "PATH", c.op == "UNSET", c.key == "JAVA_HOME_BEFORE_JAVM", c.key == javaHomesVariable:
+		case c.key == "JAVA_HOME":
+			fmt.Fprintln(&path, filepath.Join(c.value, "bin"))
+			fallthrough
+		default:
+			fmt.Fprintf(&env, "%s=%s\n", c.key, c.value)
+		}
+	}
+	if err := appendFile(envFile, env.String()); err != nil {
+		return fmt.Errorf("write GITHUB_ENV: %w", err)
+	}
+	if err := appendFile(pathFile, path.String()); err != nil {
+		return fmt.Errorf("write GITHUB_PATH: %w", err)
+	}
+	_, err := io.WriteString(w, env.String())
+	return err
+}
+
+func appendFile(path, data string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func NewEnvCommand() *cobra.Command {
+	var format string
+	var also []string
+	cmd := &cobra.Command{
+		Use:   "env [version]",
+		Short: "Print the environment variables for a JDK",
+		Long: "Print PATH and JAVA_HOME for a JDK in the format of a shell or tool, the\n" +
+			"same variables `use` sets. The version defaults to the one of .java-version,\n" +
+			"or the configured default. Formats: " + strings.Join(envFormats(), ", ") + ".",
+		Args: UsageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateEnvFormat(format); err != nil {
+				return err
+			}
+			var selector string
+			if len(args) != 0 {
+				selector = args[0]
+			} else {
+				var err error
+				if selector, err = implicitSelector(cmd.Context()); err != nil {
+					return err
+				}
+			}
+			out, err := useAlso(cmd.Context(), selector, also)
+			if err != nil {
+				return err
+			}
+			return renderEnvironment(cmd.OutOrStdout(), format, out)
+		},
+		Example: "  eval \"$(javm env 21)\"\n" +
+			"  javm env temurin@17 --format dotenv > .env\n" +
+			"  javm env --format github # in a GitHub Actions step",
+	}
+	cmd.Flags().StringSliceVar(&also, "also", nil, "also export JAVA_HOME_<major>_<ARCH> for these versions (\"installed\" for every installed major)")
+	cmd.Flags().StringVar(&format, "format", "sh", "output format ("+strings.Join(envFormats(), ", ")+")")
+	return cmd
+}
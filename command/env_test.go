@@ -0,0 +1,149 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/felipebz/javm/discovery"
+)
+
+var envTestOut = []string{
+	"SET\tPATH\t/jdk/it's 21/bin:/usr/bin",
+	"SET\tJAVA_HOME\t/jdk/it's 21",
+	"UNSET\tJAVA_HOME_BEFORE_JAVM",
+}
+
+func TestRenderEnvironment(t *testing.T) {
+	tests := map[string]string{
+		"fd3": "SET\tPATH\t/jdk/it's 21/bin:/usr/bin\nSET\tJAVA_HOME\t/jdk/it's 21\nUNSET\tJAVA_HOME_BEFORE_JAVM\n",
+		"sh":  "export PATH='/jdk/it'\\''s 21/bin:/usr/bin'\nexport JAVA_HOME='/jdk/it'\\''s 21'\nunset JAVA_HOME_BEFORE_JAVM\n",
+		"pwsh": "$env:PATH = '/jdk/it''s 21/bin:/usr/bin'\n$env:JAVA_HOME = '/jdk/it''s 21'\n" +
+			"Remove-Item -ErrorAction SilentlyContinue Env:JAVA_HOME_BEFORE_JAVM\n",
+		"cmd":    "set \"PATH=/jdk/it's 21/bin:/usr/bin\"\nset \"JAVA_HOME=/jdk/it's 21\"\nset \"JAVA_HOME_BEFORE_JAVM=\"\n",
+		"nu":     "$env.PATH = \"/jdk/it's 21/bin:/usr/bin\"\n$env.JAVA_HOME = \"/jdk/it's 21\"\nhide-env JAVA_HOME_BEFORE_JAVM\n",
+		"dotenv": "PATH=\"/jdk/it's 21/bin:/usr/bin\"\nJAVA_HOME=\"/jdk/it's 21\"\nJAVA_HOME_BEFORE_JAVM=\n",
+		"fish": "set -gx PATH (string split -- " + string(os.PathListSeparator) + " '/jdk/it\\'s 21/bin:/usr/bin')\n" +
+			"set -gx JAVA_HOME '/jdk/it\\'s 21'\nset -e JAVA_HOME_BEFORE_JAVM\n",
+		"json": "{\n  \"JAVA_HOME\": \"/jdk/it's 21\",\n  \"JAVA_HOME_BEFORE_JAVM\": null,\n  \"PATH\": \"/jdk/it's 21/bin:/usr/bin\"\n}\n",
+	}
+	for format, want := range tests {
+		var buf bytes.Buffer
+		if err := renderEnvironment(&buf, format, envTestOut); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if buf.String() != want {
+			t.Errorf("%s:\ngot  %q\nwant %q", format, buf.String(), want)
+		}
+	}
+	if err := renderEnvironment(&bytes.Buffer{}, "csh", envTestOut); !errors.Is(err, ErrUsage) {
+		t.Errorf("unknown format: got %v, want a usage error", err)
+	}
+}
+
+func TestResolvePathOps(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	changes := parseEnvChanges([]string{
+		"PATH_REMOVE_PREFIX\t/home/u/.javm/jdk/",
+		"PATH_REMOVE\t/opt/gradle-jdk/bin",
+		"PATH_PREPEND\t/home/u/.javm/jdk/temurin@21/bin",
+		"SET\tJAVA_HOME\t/home/u/.javm/jdk/temurin@21",
+	})
+	path := strings.Join([]string{"/home/u/.javm/jdk/zulu@17/bin", "/usr/bin", "/opt/gradle-jdk/bin", "/home/u/bin"}, sep)
+	resolved := resolvePathOps(changes, path)
+	want := strings.Join([]string{"/home/u/.javm/jdk/temurin@21/bin", "/usr/bin", "/home/u/bin"}, sep)
+	if len(resolved) != 2 || resolved[0] != (envChange{op: "SET", key: "PATH", value: want}) || resolved[1].key != "JAVA_HOME" {
+		t.Fatalf("resolved: %+v, want PATH %s", resolved, want)
+	}
+	// Prepending a directory already on PATH moves it to the front.
+	prepend := envChange{op: "PATH_PREPEND", key: "PATH", value: "/b"}
+	if got := applyPathOp("/a"+sep+"/b"+sep+"/c", prepend); got != "/b"+sep+"/a"+sep+"/c" {
+		t.Fatalf("prepend: %s", got)
+	}
+	// Empty entries are not javm's to remove.
+	if got := applyPathOp(sep+"/a"+sep+sep+"/b"+sep, prepend); got != "/b"+sep+sep+"/a"+sep+sep {
+		t.Fatalf("empty entries: %q", got)
+	}
+}
+
+func TestRenderShEvaluates(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	var buf bytes.Buffer
+	if err := renderEnvironment(&buf, "sh", envTestOut); err != nil {
+		t.Fatal(err)
+	}
+	out, err := exec.Command("/bin/sh", "-c", buf.String()+`printf %s "$JAVA_HOME"`).Output()
+	if err != nil || string(out) != "/jdk/it's 21" {
+		t.Fatalf("sh evaluated JAVA_HOME to %q, %v", out, err)
+	}
+}
+
+func TestRenderGitHub(t *testing.T) {
+	dir := t.TempDir()
+	envFile, pathFile := filepath.Join(dir, "env"), filepath.Join(dir, "path")
+	t.Setenv("GITHUB_ENV", envFile)
+	t.Setenv("GITHUB_PATH", pathFile)
+	if err := os.WriteFile(envFile, []byte("EXISTING=1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := append(slices.Clone(envTestOut),
+		"SET\tJAVA_HOME_BEFORE_JAVM\t/system-jdk",
+		"SET\tJAVA_HOME_21_X64\t/jdk/it's 21",
+		"UNSET\tJAVA_HOME_17_X64",
+		"SET\t"+javaHomesVariable+"\tJAVA_HOME_21_X64",
+	)
+	if err := renderEnvironment(&bytes.Buffer{}, "github", out); err != nil {
+		t.Fatal(err)
+	}
+	env, _ := os.ReadFile(envFile)
+	if string(env) != "EXISTING=1\nJAVA_HOME=/jdk/it's 21\nJAVA_HOME_21_X64=/jdk/it's 21\n" {
+		t.Errorf("GITHUB_ENV = %q", env)
+	}
+	path, _ := os.ReadFile(pathFile)
+	if string(path) != filepath.Join("/jdk/it's 21", "bin")+"\n" {
+		t.Errorf("GITHUB_PATH = %q", path)
+	}
+
+	t.Setenv("GITHUB_ENV", "")
+	if err := renderEnvironment(&bytes.Buffer{}, "github", envTestOut); !errors.Is(err, ErrUsage) {
+		t.Errorf("outside GitHub Actions: got %v, want a usage error", err)
+	}
+}
+
+func TestEnvCommandMatchesUse(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("JAVM_HOME", home)
+	t.Setenv("PATH", "/usr/bin")
+	cleanup := setupMockLs()
+	defer cleanup()
+	jdkPath := filepath.Join(home, "jdk", "temurin@21.0.1")
+	mockLsResult = []discovery.JDK{{Identifier: "temurin@21.0.1", Version: "21.0.1", Source: "javm", Path: jdkPath}}
+
+	use, err := Use("21")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want bytes.Buffer
+	if err := renderEnvironment(&want, "json", use); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewEnvCommand()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"21", "--format", "json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want.String() || !strings.Contains(buf.String(), "JAVA_HOME") {
+		t.Fatalf("env printed %s, want %s", buf.String(), want.String())
+	}
+}
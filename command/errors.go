@@ -50,3 +50,13 @@ func UsageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
 		return nil
 	}
 }
+
+// ExitStatusError reports that a command run by javm exited with Code, which
+// javm exits with as well.
+type ExitStatusError struct {
+	Code int
+}
+
+func (e *ExitStatusError) Error() string {
+	return fmt.Sprintf("command exited with status %d", e.Code)
+}
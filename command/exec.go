@@ -0,0 +1,107 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/spf13/cobra"
+)
+
+// runChild runs the program at path, replacing javm where the platform allows
+// it. It is a variable so tests can observe the child instead of becoming it.
+var runChild = execChild
+
+func NewExecCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec [version] [-- command [args...]]",
+		Short: "Run a command, or a subshell, with a specific JDK",
+		Long: "Run a command with PATH and JAVA_HOME set for a JDK, without shell integration.\n" +
+			"The version defaults to the one of .java-version, or the configured default.\n" +
+			"Without a command, an interactive subshell is started.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			selectors := args
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+				selectors = args[:dash]
+			} else if len(args) > 1 {
+				return UsageError(errors.New("separate the command from the version with --"))
+			}
+			if len(selectors) > 1 {
+				return UsageError(fmt.Errorf("expected at most one version before --, got %d", len(selectors)))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var selector string
+			var command []string
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+				command = args[dash:]
+				args = args[:dash]
+			}
+			if len(args) != 0 {
+				selector = args[0]
+			} else {
+				var err error
+				if selector, err = implicitSelector(cmd.Context()); err != nil {
+					return err
+				}
+			}
+			if len(command) == 0 {
+				command = []string{subshell()}
+			}
+
+			out, err := UseContext(cmd.Context(), selector)
+			if err != nil {
+				return err
+			}
+			if err := applyEnvironment(out); err != nil {
+				return err
+			}
+			path, err := exec.LookPath(command[0])
+			if err != nil {
+				return NotFoundError(err)
+			}
+			err = runChild(path, command, os.Environ())
+			var status *ExitStatusError
+			if errors.As(err, &status) {
+				// The child reported its own failure.
+				cmd.SilenceErrors = true
+			}
+			return err
+		},
+		Example: "  javm exec 21 -- java -version\n" +
+			"  javm exec -- ./gradlew build # version from .java-version\n" +
+			"  javm exec temurin@17         # start a subshell",
+	}
+	return cmd
+}
+
+// applyEnvironment applies the SET and UNSET lines of `use` to the environment
+// of javm, which the child inherits.
+func applyEnvironment(out []string) error {
+	for _, c := range resolvePathOps(parseEnvChanges(out), os.Getenv("PATH")) {
+		if c.op == "UNSET" {
+			if err := os.Unsetenv(c.key); err != nil {
+				return err
+			}
+		} else if err := os.Setenv(c.key, c.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func subshell() string {
+	if runtime.GOOS == "windows" {
+		if shell := os.Getenv("ComSpec"); shell != "" {
+			return shell
+		}
+		return "cmd.exe"
+	}
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell
+	}
+	return "/bin/sh"
+}
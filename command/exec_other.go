@@ -0,0 +1,17 @@
+//go:build !windows
+
+package command
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// execChild replaces javm with the child, so that it receives signals
+// directly and its exit status becomes the one of javm.
+func execChild(path string, argv []string, env []string) error {
+	if err := syscall.Exec(path, argv, env); err != nil {
+		return fmt.Errorf("exec %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/felipebz/javm/discovery"
+)
+
+func TestExecRunsCommandWithJDK(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("JAVM_HOME", home)
+	t.Setenv("JAVA_HOME", "/system-jdk")
+	t.Setenv("JAVA_HOME_BEFORE_JAVM", "")
+	os.Unsetenv("JAVA_HOME_BEFORE_JAVM")
+
+	bin := t.TempDir()
+	tool := filepath.Join(bin, "tool")
+	if runtime.GOOS == "windows" {
+		tool += ".exe"
+	}
+	if err := os.WriteFile(tool, nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	cleanup := setupMockLs()
+	defer cleanup()
+	jdkPath := filepath.Join(home, "jdk", "temurin@21.0.1")
+	mockLsResult = []discovery.JDK{{Identifier: "temurin@21.0.1", Version: "21.0.1", Source: "javm", Path: jdkPath}}
+
+	var gotPath string
+	var gotArgv, gotEnv []string
+	original := runChild
+	defer func() { runChild = original }()
+	runChild = func(path string, argv []string, env []string) error {
+		gotPath, gotArgv, gotEnv = path, argv, env
+		return &ExitStatusError{Code: 3}
+	}
+
+	cmd := NewExecCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"temurin@21", "--", "tool", "--flag"})
+	err := cmd.Execute()
+	var status *ExitStatusError
+	if !errors.As(err, &status) || status.Code != 3 {
+		t.Fatalf("child exit status was not propagated: %v", err)
+	}
+	if gotPath != tool || !slices.Equal(gotArgv, []string{"tool", "--flag"}) {
+		t.Fatalf("ran %q %v, want %q [tool --flag]", gotPath, gotArgv, tool)
+	}
+	javaHome := jdkPath
+	if runtime.GOOS == "darwin" {
+		javaHome = filepath.Join(jdkPath, "Contents", "Home")
+	}
+	if !slices.Contains(gotEnv, "JAVA_HOME="+javaHome) {
+		t.Fatalf("JAVA_HOME was not set for the child: %v", gotEnv)
+	}
+	if !slices.ContainsFunc(gotEnv, func(v string) bool {
+		return strings.HasPrefix(v, "PATH="+filepath.Join(javaHome, "bin")+string(os.PathListSeparator))
+	}) {
+		t.Fatalf("JDK was not put first on PATH: %v", gotEnv)
+	}
+}
+
+func TestExecRejectsCommandWithoutSeparator(t *testing.T) {
+	cmd := NewExecCommand()
+	cmd.SetArgs([]string{"21", "java"})
+	if err := cmd.Execute(); !errors.Is(err, ErrUsage) {
+		t.Fatalf("expected a usage error, got %v", err)
+	}
+}
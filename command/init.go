@@ -26,6 +26,29 @@ var nuInitScript string
 //go:embed shellscripts/javm.cmd
 var cmdInitScript string
 
+//go:embed shellscripts/auto.ps1
+var pwshAutoScript string
+
+//go:embed shellscripts/auto.sh
+var bashAutoScript string
+
+//go:embed shellscripts/auto.fish
+var fishAutoScript string
+
+//go:embed shellscripts/auto.nu
+var nuAutoScript string
+
+// autoScripts hook `javm use --auto` into directory changes. cmd has no hook
+// to run on cd.
+var autoScripts = map[string]string{
+	"powershell": pwshAutoScript,
+	"pwsh":       pwshAutoScript,
+	"bash":       bashAutoScript,
+	"zsh":        bashAutoScript,
+	"fish":       fishAutoScript,
+	"nu":         nuAutoScript,
+}
+
 var shellScripts = map[string]string{
 	"powershell": pwshInitScript,
 	"pwsh":       pwshInitScript,
@@ -40,7 +63,8 @@ var getExecutablePath = realGetExecutablePath
 var writePowerShellInitScript = realWritePowerShellInitScript
 
 func NewInitCommand() *cobra.Command {
-	return &cobra.Command{
+	var auto bool
+	cmd := &cobra.Command{
 		Use:   "init [shell]",
 		Short: "Print shell integration script for javm",
 		Args:  UsageArgs(cobra.ExactArgs(1)),
@@ -53,6 +77,10 @@ func NewInitCommand() *cobra.Command {
 					strings.Join(sortedShells(), ", "),
 				))
 			}
+			autoScript, autoSupported := autoScripts[shell]
+			if auto && !autoSupported {
+				return UsageError(fmt.Errorf("--auto is not supported by %s", shell))
+			}
 
 			executable, err := getExecutablePath()
 			if err != nil {
@@ -80,6 +108,9 @@ func NewInitCommand() *cobra.Command {
 			} else if defaultConfigured {
 				script += "\njavm use --default\n"
 			}
+			if auto {
+				script += autoScript
+			}
 
 			if shell == "pwsh" || shell == "powershell" {
 				scriptPath, err := writePowerShellInitScript(script)
@@ -101,7 +132,11 @@ func NewInitCommand() *cobra.Command {
 			}
 			return nil
 		},
+		Example: "  eval \"$(javm init bash)\"\n" +
+			"  eval \"$(javm init zsh --auto)\" # switch JDKs on cd using .java-version",
 	}
+	cmd.Flags().BoolVar(&auto, "auto", false, "switch JDKs automatically when entering a directory with a .java-version file")
+	return cmd
 }
 
 // escapeBatchValue protects percent signs, which cmd.exe expands even inside a
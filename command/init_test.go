@@ -2,6 +2,7 @@ package command
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
 	"slices"
@@ -321,3 +322,40 @@ func TestRealWritePowerShellInitScript(t *testing.T) {
 		t.Errorf("file content mismatch: got %q, want %q", string(data), content)
 	}
 }
+
+func TestInitAutoAppendsDirectoryHook(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	hooks := map[string]string{
+		"bash": "PROMPT_COMMAND",
+		"zsh":  "add-zsh-hook chpwd",
+		"fish": "--on-variable PWD",
+		"nu":   "env_change.PWD",
+	}
+	for shell, hook := range hooks {
+		for _, auto := range []bool{false, true} {
+			cmd := NewInitCommand()
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			args := []string{shell}
+			if auto {
+				args = append(args, "--auto")
+			}
+			cmd.SetArgs(args)
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("%v: %v", args, err)
+			}
+			if got := strings.Contains(buf.String(), hook) && strings.Contains(buf.String(), "javm use --auto"); got != auto {
+				t.Errorf("%v: hook present = %v, want %v:\n%s", args, got, auto, buf.String())
+			}
+		}
+	}
+}
+
+func TestInitAutoRejectsCMD(t *testing.T) {
+	cmd := NewInitCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"cmd", "--auto"})
+	if err := cmd.Execute(); !errors.Is(err, ErrUsage) {
+		t.Fatalf("expected a usage error, got %v", err)
+	}
+}
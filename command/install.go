@@ -10,16 +10,21 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/discoapi"
 	"github.com/felipebz/javm/discovery"
 	"github.com/felipebz/javm/semver"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
-func NewInstallCommand(client PackagesWithInfoClient) *cobra.Command {
+func NewInstallCommand(client InstallClient) *cobra.Command {
 	var customInstallDestination string
+	var osFlag string
+	var archFlag string
+	var libcFlag string
 
 	cmd := &cobra.Command{
 		Use:   "install [version to install]",
@@ -28,14 +33,18 @@ func NewInstallCommand(client PackagesWithInfoClient) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var ver string
 			if len(args) == 0 {
-				ver = cfg.ReadJavaVersion()
+				ver = projectOrBuildJavaVersion(cmd.Context())
 				if ver == "" {
 					return pflag.ErrHelp
 				}
 			} else {
 				ver = args[0]
 			}
-			ver, err := runInstall(cmd.Context(), client, ver, customInstallDestination)
+			if err := validateLibC(libcFlag); err != nil {
+				return err
+			}
+			target := newInstallTarget(normalizeOS(osFlag), archFlag, libcFlag)
+			ver, err := runInstall(cmd.Context(), client, ver, customInstallDestination, target)
 			if err != nil {
 				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 					cmd.SilenceUsage = true
@@ -43,9 +52,13 @@ func NewInstallCommand(client PackagesWithInfoClient) *cobra.Command {
 				return err
 			}
 			if customInstallDestination == "" {
+				// JDKs installed elsewhere, possibly for another platform,
+				// are not subject to lifecycle checks.
+				refreshLifecycleCache(cmd.Context(), client)
 				if err := linkLatest(cmd.Context()); err != nil {
 					return err
 				}
+				refreshShims(cmd.Context())
 				// TODO change to call the "use" command after it's refactored
 				//return use(ver)
 				return nil
@@ -54,59 +67,234 @@ func NewInstallCommand(client PackagesWithInfoClient) *cobra.Command {
 			}
 		},
 		Example: "  javm install 1.8\n" +
-			"  javm install ~1.8.73 # same as \">=1.8.73 <1.9.0\"",
+			"  javm install ~1.8.73 # same as \">=1.8.73 <1.9.0\"\n" +
+			"  javm install temurin@21 --os linux --arch arm64 --output ./out",
 	}
 	cmd.Flags().StringVarP(&customInstallDestination, "output", "o", "",
 		"New, non-existing custom destination (JDKs outside $JAVM_HOME/jdk are unmanaged unless linked)")
+	cmd.Flags().StringVar(&osFlag, "os", runtime.GOOS, "Target Operating System (macos, linux, windows); requires --output when not the host")
+	cmd.Flags().StringVar(&archFlag, "arch", runtime.GOARCH, "Target architecture (amd64, arm64, 386, arm, armv6, ppc64le, s390x, riscv64); requires --output when not the host")
+	cmd.Flags().StringVar(&libcFlag, "libc", libcFlagValue(), "C library of Linux JDKs (auto, glibc, musl); auto means glibc when --os or --arch is not the host")
 	return cmd
 }
 
-func runInstall(ctx context.Context, client PackagesWithInfoClient, selector string, dst string) (string, error) {
-	var ver *semver.Version
-	var url string
-	var err error
-	var expectedChecksum string
-	var checksumType string
+// newestInRange returns the newest version in index accepted by rng.
+func newestInRange(index *packageIndex, rng *semver.Range) *semver.Version {
+	sort.Sort(sort.Reverse(semver.VersionSlice(index.Sorted)))
+	for _, v := range index.Sorted {
+		if packageMatches(rng, v, index.ByVersion[v]) {
+			return v
+		}
+	}
+	return nil
+}
 
-	rng, err := semver.ParseRange(selector)
+// packageMatches reports whether pkg, published as version v, is selected by
+// rng. "dist:" selectors compare the vendor version instead of v.
+func packageMatches(rng *semver.Range, v *semver.Version, pkg discoapi.Package) bool {
+	if _, ok := rng.DistributionVersion(); ok {
+		return rng.MatchesQualifier(v) && rng.MatchesDistributionVersion(pkg.DistributionVersion)
+	}
+	return rng.Contains(v)
+}
+
+// installedAs returns the JDK among local that is the release ver, preferring
+// the one the configured preferences rank first.
+func installedAs(ctx context.Context, local []discovery.JDK, ver *semver.Version) (discovery.JDK, bool) {
+	var installed []discovery.JDK
+	for _, jdk := range local {
+		if isInstalledAs(jdk, ver) {
+			installed = append(installed, jdk)
+		}
+	}
+	if len(installed) == 0 {
+		return discovery.JDK{}, false
+	}
+	return slices.MinFunc(installed, loadJDKPreference(ctx).compare), true
+}
+
+// isInstalledAs reports whether jdk is the release ver. JDKs installed before
+// identifiers carried build numbers, such as "temurin@21.0.2", are the same
+// release as "temurin@21.0.2+13".
+func isInstalledAs(jdk discovery.JDK, ver *semver.Version) bool {
+	if v, err := semver.ParseVersion(jdk.Identifier); err == nil {
+		if v.Equals(ver) {
+			return true
+		}
+		if _, ok := v.Build(); !ok && v.Release() == ver.Release() {
+			return true
+		}
+	}
+	v, err := semver.ParseVersion(jdk.Version)
+	return err == nil && v.Equals(ver)
+}
+
+// findInstallCandidate returns the newest release of distribution that rng
+// selects for target, or nil when there is none, along with the releases
+// that were considered.
+func findInstallCandidate(ctx context.Context, client PackagesClient, rng *semver.Range, distribution string, target installTarget) (*packageIndex, *semver.Version, error) {
+	query := discoapi.PackageQuery{
+		OS:           target.os,
+		Arch:         target.arch,
+		Distribution: distribution,
+		LibC:         target.libc,
+		ArchiveTypes: archiveTypes(),
+	}
+	if major, ok := rng.Major(); ok && major > 1 {
+		// Let DiscoAPI do the filtering instead of downloading every release
+		// of the distribution. "1.8" asks for 8; releases older than Java 5
+		// keep a major of 1 and are filtered here.
+		query.JDKVersion = major
+		query.LatestOnly = rng.CoversMajor()
+	}
+	index, err := makePackageIndex(ctx, client, query)
 	if err != nil {
-		return "", UsageError(err)
+		return nil, nil, err
 	}
-	distribution := rng.Qualifier
-	if distribution == "" {
-		var derr error
-		distribution, derr = cfg.EffectiveValue("java.default_distribution")
-		if derr != nil {
-			return "", derr
+	if rng, err = resolveRange(ctx, rng, remoteReleases(index)); err != nil {
+		return index, nil, err
+	}
+	ver := newestInRange(index, rng)
+	if ver == nil && query.LatestOnly {
+		// The newest release may not be packaged for this platform.
+		query.LatestOnly = false
+		if index, err = makePackageIndex(ctx, client, query); err != nil {
+			return nil, nil, err
 		}
+		ver = newestInRange(index, rng)
+	}
+	return index, ver, nil
+}
+
+// installTarget is the platform a JDK is downloaded for. It defaults to the
+// host, but can name another platform when preparing JDKs for images or bundles.
+type installTarget struct {
+	os   string
+	arch string
+	libc string
+}
+
+// newInstallTarget returns the target for the --os, --arch and --libc flags.
+// The C library of the host says nothing about another platform, so "auto"
+// stands for glibc there.
+func newInstallTarget(goos, arch, libc string) installTarget {
+	target := installTarget{os: goos, arch: arch, libc: libc}
+	if !target.isHost() && target.libc == discoapi.LibCAuto {
+		target.libc = discoapi.LibCGlibc
 	}
-	packageIndex, err := makePackageIndex(ctx, client, runtime.GOOS, runtime.GOARCH, distribution)
+	return target
+}
+
+func hostInstallTarget() installTarget {
+	return installTarget{os: runtime.GOOS, arch: runtime.GOARCH, libc: discoapi.LibCAuto}
+}
+
+func (t installTarget) isHost() bool {
+	return t.os == runtime.GOOS && t.arch == runtime.GOARCH
+}
+
+func (t installTarget) String() string {
+	return t.os + "/" + t.arch
+}
+
+// resolveRemote returns the newest release selector picks among the packages
+// DiscoAPI lists for target, trying the distributions in the order the
+// selector names them, or the default distribution.
+func resolveRemote(ctx context.Context, client CatalogClient, selector string, target installTarget) (*packageIndex, *semver.Version, error) {
+	rng, err := semver.ParseRange(selector)
 	if err != nil {
-		return "", err
+		return nil, nil, UsageError(err)
 	}
-	sort.Sort(sort.Reverse(semver.VersionSlice(packageIndex.Sorted)))
-	for _, v := range packageIndex.Sorted {
-		if rng.Contains(v) {
-			ver = v
-			packageInfo, err := client.GetPackageInfoContext(ctx, packageIndex.ByVersion[ver].Id)
-			if err != nil {
-				return "", NetworkError(err)
+	known := knownDistributions(ctx, client)
+	var distributions []string
+	if rng.Qualifier == "" {
+		distribution, err := cfg.EffectiveValue("java.default_distribution")
+		if err != nil {
+			return nil, nil, err
+		}
+		if distribution, err = canonicalizeDistribution(ctx, distribution, known); err != nil {
+			return nil, nil, err
+		}
+		distributions = []string{distribution}
+	} else {
+		if rng, err = mapQualifiers(rng, func(name string) (string, error) {
+			return canonicalizeDistribution(ctx, name, known)
+		}); err != nil {
+			return nil, nil, err
+		}
+		if distributions, err = installDistributions(rng, known); err != nil {
+			return nil, nil, err
+		}
+	}
+	symbolic := rng.Symbol() != ""
+	var packageIndex *packageIndex
+	var ver *semver.Version
+	var targets []string
+	// Distributions are tried in the order the selector lists them.
+	for i, distribution := range distributions {
+		candidate := rng
+		if rng.Qualifier != "" {
+			candidate = rng.WithQualifier(distribution)
+		}
+		packageIndex, ver, err = findInstallCandidate(ctx, client, candidate, distribution, target)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) && i < len(distributions)-1 {
+				continue
 			}
-
-			expectedChecksum = packageInfo.Checksum
-			checksumType = packageInfo.ChecksumType
-			url = packageInfo.DirectDownloadUri
+			return nil, nil, err
+		}
+		if ver != nil {
 			break
 		}
+		for _, v := range packageIndex.Sorted {
+			targets = append(targets, v.String())
+		}
 	}
 	if ver == nil {
-		tt := make([]string, len(packageIndex.Sorted))
-		for i, v := range packageIndex.Sorted {
-			tt[i] = v.String()
+		return nil, nil, NotFoundError(errors.New("No compatible version found for " + selector +
+			"\nValid install targets: " + strings.Join(targets, ", ")))
+	}
+	if symbolic {
+		loggerFromContext(ctx).Info(selector, " resolved to ", ver)
+	}
+	return packageIndex, ver, nil
+}
+
+func runInstall(ctx context.Context, client InstallClient, selector string, dst string, target installTarget) (string, error) {
+	var url string
+	var expectedChecksum string
+	var checksumType string
+
+	switch target.os {
+	case "darwin", "linux", "windows":
+	default:
+		return "", UsageError(errors.New(target.os + " OS is not supported"))
+	}
+	if !target.isHost() {
+		// A foreign JDK cannot run here, so it must never become a managed JDK
+		// that `use` or `linkLatest` could pick up.
+		managedDir := filepath.Join(cfg.Dir(), "jdk")
+		if dst == "" {
+			return "", UsageError(fmt.Errorf("installing for %s requires --output; JDKs for other platforms are never added to %s",
+				target, managedDir))
+		}
+		if pathWithinRoot(managedDir, dst) {
+			return "", UsageError(fmt.Errorf("--output %s is inside %s, where JDKs for other platforms are never added", dst, managedDir))
 		}
-		return "", NotFoundError(errors.New("No compatible version found for " + selector +
-			"\nValid install targets: " + strings.Join(tt, ", ")))
 	}
+	packageIndex, ver, err := resolveRemote(ctx, client, selector, target)
+	if err != nil {
+		return "", err
+	}
+	pkg := packageIndex.ByVersion[ver]
+	packageInfo, err := client.GetPackageInfoContext(ctx, pkg.Id)
+	if err != nil {
+		return "", NetworkError(err)
+	}
+
+	expectedChecksum = packageInfo.Checksum
+	checksumType = packageInfo.ChecksumType
+	url = packageInfo.DirectDownloadUri
 
 	// check whether requested version is already installed
 	if dst == "" {
@@ -114,15 +302,13 @@ func runInstall(ctx context.Context, client PackagesWithInfoClient, selector str
 		if err != nil {
 			return "", err
 		}
-		if slices.ContainsFunc(local, func(jdk discovery.JDK) bool {
-			v, _ := semver.ParseVersion(jdk.Version)
-			vID, _ := semver.ParseVersion(jdk.Identifier)
-			return (v != nil && v.Equals(ver)) || (vID != nil && vID.Equals(ver))
-		}) {
-			return ver.String(), nil
+		if jdk, ok := installedAs(ctx, local, ver); ok {
+			loggerFromContext(ctx).Info(ver, " is already installed as ", jdk.Identifier)
+			return jdk.Identifier, nil
 		}
 	}
-	if dst == "" {
+	managed := dst == ""
+	if managed {
 		dst = filepath.Join(cfg.Dir(), "jdk", ver.String())
 	}
 	var file string
@@ -134,7 +320,11 @@ func runInstall(ctx context.Context, client PackagesWithInfoClient, selector str
 			file = strings.Replace(strings.TrimPrefix(file, "/"), "/", "\\", -1)
 		}
 	} else {
-		loggerFromContext(ctx).Info("Downloading ", ver)
+		if target.isHost() {
+			loggerFromContext(ctx).Info("Downloading ", ver)
+		} else {
+			loggerFromContext(ctx).Info("Downloading ", ver, " for ", target)
+		}
 		loggerFromContext(ctx).Debug("URL: ", url)
 		file, err = download(ctx, url)
 		if err != nil {
@@ -156,11 +346,24 @@ func runInstall(ctx context.Context, client PackagesWithInfoClient, selector str
 	} else {
 		loggerFromContext(ctx).Warn("No checksum provided by DiscoAPI for this artifact; skipping integrity verification")
 	}
-	switch runtime.GOOS {
-	case "darwin", "linux", "windows":
-		err = install(ctx, file, dst)
-	default:
-		err = errors.New(runtime.GOOS + " OS is not supported")
+	if err := installFor(ctx, file, dst, target.os); err != nil {
+		return ver.String(), err
+	}
+	if managed {
+		receipt := installReceipt{
+			Distribution:        pkg.Distribution,
+			JavaVersion:         pkg.JavaVersion,
+			DistributionVersion: pkg.DistributionVersion,
+			PackageID:           pkg.Id,
+			ArchiveType:         pkg.ArchiveType,
+			URL:                 url,
+			Checksum:            expectedChecksum,
+			ChecksumType:        checksumType,
+			Installed:           time.Now().UTC(),
+		}
+		if err := saveReceipt(ver.String(), receipt); err != nil {
+			loggerFromContext(ctx).Warn("Failed to save install receipt: ", err)
+		}
 	}
-	return ver.String(), err
+	return ver.String(), nil
 }
@@ -33,10 +33,16 @@ type extractionLimits struct {
 type archiveExtractor func(context.Context, string, string) error
 
 func install(ctx context.Context, file string, dst string) (err error) {
-	return installWithExtractor(ctx, file, dst, extractArchive)
+	return installFor(ctx, file, dst, runtime.GOOS)
 }
 
-func installWithExtractor(ctx context.Context, file string, dst string, extract archiveExtractor) (err error) {
+// installFor extracts file into dst and validates the result against the JDK
+// layout expected on goos, which may differ from the host operating system.
+func installFor(ctx context.Context, file string, dst string, goos string) (err error) {
+	return installWithExtractor(ctx, file, dst, goos, extractArchive)
+}
+
+func installWithExtractor(ctx context.Context, file string, dst string, goos string, extract archiveExtractor) (err error) {
 	if err := ctx.Err(); err != nil {
 		return err
 	}
@@ -73,11 +79,11 @@ func installWithExtractor(ctx context.Context, file string, dst string, extract
 		return fmt.Errorf("extract archive into staging: %w; installation rolled back", err)
 	}
 
-	readyRoot, err := prepareStagedJDK(ctx, extractRoot, transactionDir, runtime.GOOS)
+	readyRoot, err := prepareStagedJDK(ctx, extractRoot, transactionDir, goos)
 	if err != nil {
 		return fmt.Errorf("validate staged JDK: %w; installation rolled back", err)
 	}
-	if err := assertJavaDistribution(readyRoot, runtime.GOOS); err != nil {
+	if err := assertJavaDistribution(readyRoot, goos); err != nil {
 		return fmt.Errorf("validate staged JDK: %w; installation rolled back", err)
 	}
 	if err := ctx.Err(); err != nil {
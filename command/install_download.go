@@ -210,5 +210,8 @@ func getFileExtension(file string) string {
 	if strings.HasSuffix(lower, ".tar.xz") {
 		return ".tar.xz"
 	}
+	if strings.HasSuffix(lower, ".tgz") {
+		return ".tar.gz"
+	}
 	return strings.ToLower(filepath.Ext(file))
 }
@@ -51,7 +51,7 @@ func TestInstallCancellationCleansOwnedStaging(t *testing.T) {
 	dst := filepath.Join(parent, "jdk")
 	ctx, cancel := context.WithCancel(context.Background())
 
-	err := installWithExtractor(ctx, "jdk.zip", dst, func(ctx context.Context, _, staging string) error {
+	err := installWithExtractor(ctx, "jdk.zip", dst, runtime.GOOS, func(ctx context.Context, _, staging string) error {
 		if err := os.WriteFile(filepath.Join(staging, "partial"), []byte("partial"), 0600); err != nil {
 			return err
 		}
@@ -371,13 +371,21 @@ func (r *cancelAfterRead) Read(p []byte) (int, error) {
 	return n, err
 }
 
-func (c installPackagesClient) GetPackagesContext(ctx context.Context, _, _, _, _ string) ([]discoapi.Package, error) {
+func (c installPackagesClient) GetPackagesContext(ctx context.Context, _ discoapi.PackageQuery) ([]discoapi.Package, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 	return []discoapi.Package{{Id: "jdk", Distribution: "temurin", JavaVersion: "21.0.1"}}, nil
 }
 
+func (c installPackagesClient) GetDistributionsContext(context.Context) ([]discoapi.Distribution, error) {
+	return nil, errors.New("no distribution list")
+}
+
+func (c installPackagesClient) GetMajorVersionsContext(context.Context) ([]discoapi.MajorVersion, error) {
+	return nil, errors.New("no major versions")
+}
+
 func (c installPackagesClient) GetPackageInfoContext(ctx context.Context, _ string) (*discoapi.PackageInfo, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -397,7 +405,7 @@ func TestRunInstallVerifiesLocalArtifactAndPromotes(t *testing.T) {
 	}
 	checksum := fmt.Sprintf("%x", sha256.Sum256(data))
 	dst := filepath.Join(t.TempDir(), "jdk")
-	version, err := runInstall(context.Background(), installPackagesClient{archivePath: archive, checksum: checksum}, "21", dst)
+	version, err := runInstall(context.Background(), installPackagesClient{archivePath: archive, checksum: checksum}, "21", dst, hostInstallTarget())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -412,7 +420,7 @@ func TestRunInstallVerifiesLocalArtifactAndPromotes(t *testing.T) {
 func TestRunInstallChecksumFailureDoesNotCreateDestination(t *testing.T) {
 	archive := makeZipArchive(t, []zipTestEntry{{name: javaArchivePath(), body: "java", mode: 0755}})
 	dst := filepath.Join(t.TempDir(), "jdk")
-	_, err := runInstall(context.Background(), installPackagesClient{archivePath: archive, checksum: strings.Repeat("0", sha256.Size*2)}, "21", dst)
+	_, err := runInstall(context.Background(), installPackagesClient{archivePath: archive, checksum: strings.Repeat("0", sha256.Size*2)}, "21", dst, hostInstallTarget())
 	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
 		t.Fatalf("expected checksum error, got %v", err)
 	}
@@ -424,6 +432,74 @@ func TestRunInstallChecksumFailureDoesNotCreateDestination(t *testing.T) {
 	}
 }
 
+func TestRunInstallForForeignPlatformUsesTargetLayout(t *testing.T) {
+	target := installTarget{os: "windows", arch: "arm64"}
+	javaPath := "jdk/bin/java.exe"
+	if runtime.GOOS == "windows" {
+		target = installTarget{os: "linux", arch: "arm64"}
+		javaPath = "jdk/bin/java"
+	}
+	archive := makeZipArchive(t, []zipTestEntry{{name: javaPath, body: "java", mode: 0755}})
+	data, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksum := fmt.Sprintf("%x", sha256.Sum256(data))
+	dst := filepath.Join(t.TempDir(), "jdk")
+	if _, err := runInstall(context.Background(), installPackagesClient{archivePath: archive, checksum: checksum}, "21", dst, target); err != nil {
+		t.Fatal(err)
+	}
+	if err := assertJavaDistribution(dst, target.os); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunInstallForForeignPlatformRequiresOutput(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	target := installTarget{os: "linux", arch: "s390x"}
+	if runtime.GOOS == "linux" && runtime.GOARCH == "s390x" {
+		target.arch = "amd64"
+	}
+	_, err := runInstall(context.Background(), installPackagesClient{}, "21", "", target)
+	if !errors.Is(err, ErrUsage) || !strings.Contains(err.Error(), "requires --output") {
+		t.Fatalf("expected usage error requiring --output, got %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(os.Getenv("JAVM_HOME"), "jdk")); !os.IsNotExist(statErr) {
+		t.Fatalf("managed JDK directory was touched: %v", statErr)
+	}
+	// --output cannot put it in the managed directory either.
+	t.Chdir(os.Getenv("JAVM_HOME"))
+	for _, dst := range []string{
+		filepath.Join(os.Getenv("JAVM_HOME"), "jdk", "foo"),
+		filepath.Join("jdk", "..", "jdk", "foo"),
+		"jdk",
+	} {
+		_, err := runInstall(context.Background(), installPackagesClient{}, "21", dst, target)
+		if !errors.Is(err, ErrUsage) {
+			t.Errorf("--output %s: expected usage error, got %v", dst, err)
+		}
+	}
+	if _, statErr := os.Stat(filepath.Join(os.Getenv("JAVM_HOME"), "jdk")); !os.IsNotExist(statErr) {
+		t.Fatalf("managed JDK directory was touched: %v", statErr)
+	}
+}
+
+func TestNewInstallTargetResolvesLibCForForeignPlatforms(t *testing.T) {
+	foreignArch := "s390x"
+	if runtime.GOARCH == foreignArch {
+		foreignArch = "amd64"
+	}
+	if got := newInstallTarget("linux", foreignArch, discoapi.LibCAuto).libc; got != discoapi.LibCGlibc {
+		t.Errorf("foreign target with auto libc = %q, want glibc", got)
+	}
+	if got := newInstallTarget("linux", foreignArch, discoapi.LibCMusl).libc; got != discoapi.LibCMusl {
+		t.Errorf("explicit musl was replaced with %q", got)
+	}
+	if got := newInstallTarget(runtime.GOOS, runtime.GOARCH, discoapi.LibCAuto).libc; got != discoapi.LibCAuto {
+		t.Errorf("host target libc = %q, want auto", got)
+	}
+}
+
 func assertUnsafeInstall(t *testing.T, archive, errorPart string) {
 	t.Helper()
 	parent := t.TempDir()
@@ -557,3 +633,149 @@ func writeTarEntries(t *testing.T, tw *tar.Writer, entries []tarTestEntry) {
 		}
 	}
 }
+
+type queryRecordingClient struct {
+	queries  []discoapi.PackageQuery
+	packages func(discoapi.PackageQuery) []discoapi.Package
+}
+
+func (c *queryRecordingClient) GetPackagesContext(_ context.Context, query discoapi.PackageQuery) ([]discoapi.Package, error) {
+	c.queries = append(c.queries, query)
+	return c.packages(query), nil
+}
+
+func (c *queryRecordingClient) GetPackageInfoContext(context.Context, string) (*discoapi.PackageInfo, error) {
+	return nil, errors.New("stop before download")
+}
+
+func (c *queryRecordingClient) GetDistributionsContext(context.Context) ([]discoapi.Distribution, error) {
+	return nil, errors.New("no distribution list")
+}
+
+func (c *queryRecordingClient) GetMajorVersionsContext(context.Context) ([]discoapi.MajorVersion, error) {
+	return nil, errors.New("no major versions")
+}
+
+func TestRunInstallPushesMajorVersionToDiscoAPI(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	tests := []struct {
+		selector string
+		want     []discoapi.PackageQuery
+	}{
+		{"temurin@21", []discoapi.PackageQuery{{JDKVersion: 21, LatestOnly: true}}},
+		{"temurin@~21.0.1", []discoapi.PackageQuery{{JDKVersion: 21}}},
+		{"temurin@>=17 <22", []discoapi.PackageQuery{{}}},
+	}
+	for _, tt := range tests {
+		client := &queryRecordingClient{packages: func(discoapi.PackageQuery) []discoapi.Package {
+			return []discoapi.Package{{Id: "jdk", Distribution: "temurin", JavaVersion: "21.0.1+12"}}
+		}}
+		if _, err := runInstall(context.Background(), client, tt.selector, "", hostInstallTarget()); !errors.Is(err, ErrNetwork) {
+			t.Fatalf("%s: expected the package info error, got %v", tt.selector, err)
+		}
+		if len(client.queries) != len(tt.want) {
+			t.Fatalf("%s: made %d queries, want %d", tt.selector, len(client.queries), len(tt.want))
+		}
+		for i, want := range tt.want {
+			got := client.queries[i]
+			if got.JDKVersion != want.JDKVersion || got.LatestOnly != want.LatestOnly {
+				t.Errorf("%s: query %d = {JDKVersion: %d, LatestOnly: %v}, want {%d, %v}",
+					tt.selector, i, got.JDKVersion, got.LatestOnly, want.JDKVersion, want.LatestOnly)
+			}
+		}
+	}
+}
+
+func TestRunInstallRetriesWithoutLatestWhenNothingMatches(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	client := &queryRecordingClient{packages: func(query discoapi.PackageQuery) []discoapi.Package {
+		if query.LatestOnly {
+			return nil
+		}
+		return []discoapi.Package{{Id: "jdk", Distribution: "temurin", JavaVersion: "21.0.1+12"}}
+	}}
+	if _, err := runInstall(context.Background(), client, "temurin@21", "", hostInstallTarget()); !errors.Is(err, ErrNetwork) {
+		t.Fatalf("expected the package info error, got %v", err)
+	}
+	if len(client.queries) != 2 || !client.queries[0].LatestOnly || client.queries[1].LatestOnly {
+		t.Fatalf("queries = %+v, want a latest-only query followed by a full one", client.queries)
+	}
+}
+
+func TestRunInstallResolvesLTSFromDiscoAPI(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	var infoID string
+	client := &queryRecordingClient{packages: func(discoapi.PackageQuery) []discoapi.Package {
+		return []discoapi.Package{
+			{Id: "22", Distribution: "temurin", JavaVersion: "22.0.2+9", TermOfSupport: "sts"},
+			{Id: "21", Distribution: "temurin", JavaVersion: "21.0.4+7", TermOfSupport: "lts"},
+			{Id: "17", Distribution: "temurin", JavaVersion: "17.0.12+7", TermOfSupport: "lts"},
+		}
+	}}
+	resolving := &packageInfoRecorder{queryRecordingClient: client, id: &infoID}
+
+	for selector, want := range map[string]string{"temurin@lts": "21", "temurin@previous-lts": "17", "temurin@latest": "22"} {
+		if _, err := runInstall(context.Background(), resolving, selector, "", hostInstallTarget()); !errors.Is(err, ErrNetwork) {
+			t.Fatalf("%s: expected the package info error, got %v", selector, err)
+		}
+		if infoID != want {
+			t.Errorf("%s installed package %q, want %q", selector, infoID, want)
+		}
+	}
+}
+
+type packageInfoRecorder struct {
+	*queryRecordingClient
+	id *string
+}
+
+func (c *packageInfoRecorder) GetPackageInfoContext(ctx context.Context, id string) (*discoapi.PackageInfo, error) {
+	*c.id = id
+	return c.queryRecordingClient.GetPackageInfoContext(ctx, id)
+}
+
+func TestRunInstallSelectsByDistributionVersion(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	var infoID string
+	client := &queryRecordingClient{packages: func(discoapi.PackageQuery) []discoapi.Package {
+		return []discoapi.Package{
+			{Id: "new", Distribution: "zulu", JavaVersion: "21.0.4+7", DistributionVersion: "21.36.17"},
+			{Id: "old", Distribution: "zulu", JavaVersion: "21.0.3+9", DistributionVersion: "21.34.19"},
+			{Id: "older", Distribution: "zulu", JavaVersion: "21.0.2+13", DistributionVersion: "21.32.17"},
+		}
+	}}
+	resolving := &packageInfoRecorder{queryRecordingClient: client, id: &infoID}
+
+	for selector, want := range map[string]string{"zulu@dist:21.32.17": "older", "zulu@dist:21.34": "old", "zulu@dist:21": "new"} {
+		if _, err := runInstall(context.Background(), resolving, selector, "", hostInstallTarget()); !errors.Is(err, ErrNetwork) {
+			t.Fatalf("%s: expected the package info error, got %v", selector, err)
+		}
+		if infoID != want {
+			t.Errorf("%s installed package %q, want %q", selector, infoID, want)
+		}
+	}
+	if _, err := runInstall(context.Background(), resolving, "zulu@dist:21.30", "", hostInstallTarget()); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected not found for an unknown distribution version, got %v", err)
+	}
+}
+
+func TestRunInstallWritesReceiptForManagedJDKs(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	archive := makeZipArchive(t, []zipTestEntry{{name: javaArchivePath(), body: "java", mode: 0755}})
+	data, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksum := fmt.Sprintf("%x", sha256.Sum256(data))
+	version, err := runInstall(context.Background(), installPackagesClient{archivePath: archive, checksum: checksum}, "21", "", hostInstallTarget())
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, ok, err := loadReceipt(version)
+	if err != nil || !ok {
+		t.Fatalf("receipt for %s was not written: ok=%v, err=%v", version, ok, err)
+	}
+	if receipt.PackageID != "jdk" || receipt.JavaVersion != "21.0.1" || receipt.Checksum != checksum || receipt.Installed.IsZero() {
+		t.Fatalf("unexpected receipt %+v", receipt)
+	}
+}
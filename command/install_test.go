@@ -142,6 +142,7 @@ func TestGetFileExtension(t *testing.T) {
 		{"file.zip", ".zip"},
 		{"file.txt", ".txt"},
 		{"path/to/file.tar.gz", ".tar.gz"},
+		{"file.TGZ", ".tar.gz"},
 	}
 
 	for _, tt := range tests {
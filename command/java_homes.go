@@ -0,0 +1,138 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/discovery"
+	"github.com/felipebz/javm/semver"
+)
+
+// allInstalledHomes selects the newest JDK of every installed major for
+// JAVA_HOME_<major>_<ARCH> variables.
+const allInstalledHomes = "installed"
+
+// javaHomesVariable lists the JAVA_HOME_<major>_<ARCH> variables javm set, so
+// that the next `use` or `deactivate` can remove them.
+const javaHomesVariable = "JAVM_JAVA_HOMES"
+
+// javaHomeVariables returns the environment changes exporting
+// JAVA_HOME_<major>_<ARCH> for jdk and for the JDKs selected by also and by
+// java.home_variables, the way GitHub's setup-java does for Gradle
+// toolchains. Variables set by a previous call that are no longer wanted are
+// unset.
+func javaHomeVariables(ctx context.Context, jdk discovery.JDK, jdks []discovery.JDK, also []string) ([]string, error) {
+	value, err := cfg.EffectiveValue("java.home_variables")
+	if err != nil {
+		return nil, err
+	}
+	selectors := append(slices.Clone(also), cfg.SplitList(value)...)
+	var out, names []string
+	if len(selectors) != 0 {
+		selected := []discovery.JDK{jdk}
+		for _, selector := range selectors {
+			more, err := selectHomeJDKs(ctx, jdks, selector)
+			if err != nil {
+				return nil, err
+			}
+			selected = append(selected, more...)
+		}
+		for _, jdk := range selected {
+			name, err := javaHomeName(jdk)
+			if err != nil {
+				return nil, err
+			}
+			if slices.Contains(names, name) {
+				continue
+			}
+			home, err := jdkHome(jdk.Path)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, name)
+			out = append(out, "SET\t"+name+"\t"+home)
+		}
+	}
+	for _, name := range exportedJavaHomes() {
+		if !slices.Contains(names, name) {
+			out = append(out, "UNSET\t"+name)
+		}
+	}
+	if len(names) != 0 {
+		out = append(out, "SET\t"+javaHomesVariable+"\t"+strings.Join(names, ","))
+	} else if _, ok := os.LookupEnv(javaHomesVariable); ok {
+		out = append(out, "UNSET\t"+javaHomesVariable)
+	}
+	return out, nil
+}
+
+// selectHomeJDKs returns the JDK selector picks, or with allInstalledHomes,
+// the preferred JDK of each installed major.
+func selectHomeJDKs(ctx context.Context, jdks []discovery.JDK, selector string) ([]discovery.JDK, error) {
+	if selector != allInstalledHomes {
+		if alias := getAlias(selector); alias != "" {
+			selector = alias
+		}
+		jdk, err := FindBestMatchJDKContext(ctx, slices.Clone(jdks), selector)
+		if err != nil {
+			return nil, err
+		}
+		return []discovery.JDK{jdk}, nil
+	}
+	var majors []uint64
+	for _, jdk := range jdks {
+		if v, err := semver.ParseVersion(jdk.Version); err == nil && !slices.Contains(majors, v.Major()) {
+			majors = append(majors, v.Major())
+		}
+	}
+	slices.Sort(majors)
+	var selected []discovery.JDK
+	for _, major := range majors {
+		// Unlike listed versions, a major that cannot be selected, for example
+		// because of the preferences, must not fail the primary JAVA_HOME.
+		jdk, err := FindBestMatchJDKContext(ctx, slices.Clone(jdks), strconv.FormatUint(major, 10))
+		if err != nil {
+			loggerFromContext(ctx).Debug("Skipping JAVA_HOME variable for Java ", major, ": ", err)
+			continue
+		}
+		if _, err := javaHomeName(jdk); err != nil {
+			loggerFromContext(ctx).Debug("Skipping JAVA_HOME variable for Java ", major, ": ", err)
+			continue
+		}
+		selected = append(selected, jdk)
+	}
+	return selected, nil
+}
+
+// javaHomeName returns the name of the variable for jdk, such as
+// JAVA_HOME_21_X64 or JAVA_HOME_17_ARM64.
+func javaHomeName(jdk discovery.JDK) (string, error) {
+	v, err := semver.ParseVersion(jdk.Version)
+	if err != nil {
+		return "", fmt.Errorf("read version of %s: %w", jdk.Path, err)
+	}
+	arch := jdk.Architecture
+	if arch == "" {
+		arch = runtime.GOARCH
+	}
+	switch arch {
+	case "x64", "amd64":
+		arch = "x64"
+	case "aarch64", "arm64":
+		arch = "arm64"
+	case "386":
+		arch = "x86"
+	}
+	return fmt.Sprintf("JAVA_HOME_%d_%s", v.Major(), strings.ToUpper(arch)), nil
+}
+
+// exportedJavaHomes returns the variables listed in JAVM_JAVA_HOMES.
+func exportedJavaHomes() []string {
+	return cfg.SplitList(os.Getenv(javaHomesVariable))
+}
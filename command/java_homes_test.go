@@ -0,0 +1,104 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/discovery"
+)
+
+func TestJavaHomeVariables(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	t.Setenv(javaHomesVariable, "JAVA_HOME_8_X64,JAVA_HOME_21_X64")
+	cleanup := setupMockLs()
+	defer cleanup()
+	jdk := func(id, version, arch string) discovery.JDK {
+		return discovery.JDK{Identifier: id, Version: version, Architecture: arch, Source: "javm", Path: filepath.Join(cfg.Dir(), "jdk", id)}
+	}
+	mockLsResult = []discovery.JDK{
+		jdk("temurin@11.0.22", "11.0.22", "x64"),
+		jdk("temurin@17.0.10", "17.0.10", "x64"),
+		jdk("temurin@17.0.9", "17.0.9", "x64"),
+		jdk("temurin@21.0.2", "21.0.2", "aarch64"),
+	}
+	home := func(id string) string {
+		path, err := jdkHome(filepath.Join(cfg.Dir(), "jdk", id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	out, err := useAlso(context.Background(), "21", []string{"17", "11"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"SET\tJAVA_HOME_21_ARM64\t" + home("temurin@21.0.2"),
+		"SET\tJAVA_HOME_17_X64\t" + home("temurin@17.0.10"),
+		"SET\tJAVA_HOME_11_X64\t" + home("temurin@11.0.22"),
+		"UNSET\tJAVA_HOME_8_X64",
+		"UNSET\tJAVA_HOME_21_X64",
+		"SET\t" + javaHomesVariable + "\tJAVA_HOME_21_ARM64,JAVA_HOME_17_X64,JAVA_HOME_11_X64",
+	}
+	if got := out[len(out)-len(want):]; !slices.Equal(got, want) {
+		t.Fatalf("got %q\nwant %q", got, want)
+	}
+
+	// The config option applies to every `use`, and "installed" takes each
+	// installed major once, skipping majors that cannot be selected.
+	if err := cfg.SetValue("java.home_variables", allInstalledHomes); err != nil {
+		t.Fatal(err)
+	}
+	os.Unsetenv(javaHomesVariable)
+	// The identifier of this JDK disagrees with its version, so "8" selects nothing.
+	mockLsResult = append(mockLsResult, discovery.JDK{Identifier: "custom@99", Version: "8.0.402", Source: "javm", Path: "/tmp/jdk/custom"})
+	out, err = UseContext(context.Background(), "17")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(out, "SET\t"+javaHomesVariable+"\tJAVA_HOME_17_X64,JAVA_HOME_11_X64,JAVA_HOME_21_ARM64") {
+		t.Fatalf("installed majors were not exported: %q", out)
+	}
+	// Versions listed explicitly must resolve.
+	if _, err := useAlso(context.Background(), "17", []string{"8"}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("--also 8: got %v, want a not found error", err)
+	}
+}
+
+func TestUseWithoutJavaHomeVariables(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	t.Setenv(javaHomesVariable, "")
+	os.Unsetenv(javaHomesVariable)
+	cleanup := setupMockLs()
+	defer cleanup()
+	mockLsResult = []discovery.JDK{
+		{Identifier: "temurin@21.0.2", Version: "21.0.2", Source: "javm", Path: filepath.Join(cfg.Dir(), "jdk", "temurin@21.0.2")},
+	}
+	out, err := Use("21")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out[len(out)-1] != "SET\tJAVA_HOME_BEFORE_JAVM\t"+os.Getenv("JAVA_HOME") {
+		t.Fatalf("unexpected changes: %q", out)
+	}
+}
+
+func TestDeactivateRemovesJavaHomeVariables(t *testing.T) {
+	t.Setenv("JAVA_HOME", "/opt/jdk-21")
+	t.Setenv("JAVA_HOME_BEFORE_JAVM", "/opt/jdk-21")
+	t.Setenv(javaHomesVariable, "JAVA_HOME_17_X64,JAVA_HOME_21_X64")
+	out, err := deactivate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"UNSET\tJAVA_HOME_17_X64", "UNSET\tJAVA_HOME_21_X64", "UNSET\t" + javaHomesVariable}
+	if got := out[len(out)-len(want):]; !slices.Equal(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,119 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/felipebz/javm/discoapi"
+	"github.com/felipebz/javm/discovery"
+	"github.com/felipebz/javm/semver"
+	"github.com/spf13/cobra"
+)
+
+type MajorVersionsClient interface {
+	GetMajorVersionsContext(ctx context.Context) ([]discoapi.MajorVersion, error)
+}
+
+func NewLifecycleCommand(client MajorVersionsClient) *cobra.Command {
+	var showAll bool
+	cmd := &cobra.Command{
+		Use:   "lifecycle",
+		Short: "Show support status of Java major versions and the JDKs installed for each",
+		Args:  UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			majors, err := client.GetMajorVersionsContext(cmd.Context())
+			if err != nil {
+				return NetworkError(err)
+			}
+			if err := saveLifecycleCache(majors); err != nil {
+				loggerFromContext(cmd.Context()).Warn(err)
+			}
+			jdks, err := LsContext(cmd.Context(), false)
+			if err != nil {
+				return err
+			}
+			return printLifecycle(cmd.OutOrStdout(), majors, jdks, showAll)
+		},
+	}
+	cmd.Flags().BoolVarP(&showAll, "all", "a", false, "Include end-of-life major versions without installed JDKs")
+	return cmd
+}
+
+func printLifecycle(w io.Writer, majors []discoapi.MajorVersion, jdks []discovery.JDK, showAll bool) error {
+	installed := installedByFeatureRelease(jdks)
+	majors = slices.Clone(majors)
+	slices.SortFunc(majors, func(a, b discoapi.MajorVersion) int { return b.MajorVersion - a.MajorVersion })
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "MAJOR\tSUPPORT\tSTATUS\tLATEST GA\tINSTALLED"); err != nil {
+		return fmt.Errorf("write lifecycle header: %w", err)
+	}
+	for _, major := range majors {
+		identifiers := installed[uint64(major.MajorVersion)]
+		if !showAll && !major.Maintained && len(identifiers) == 0 {
+			continue
+		}
+		status := "maintained"
+		if !major.Maintained {
+			status = "end of life"
+		}
+		if _, err := fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n",
+			major.MajorVersion,
+			valueOrDash(strings.ToUpper(major.TermOfSupport)),
+			status,
+			valueOrDash(newestGA(major.Versions)),
+			valueOrDash(strings.Join(identifiers, ", ")),
+		); err != nil {
+			return fmt.Errorf("write lifecycle of Java %d: %w", major.MajorVersion, err)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush lifecycle output: %w", err)
+	}
+	return nil
+}
+
+// installedByFeatureRelease groups the identifiers of jdks by Java feature
+// release, reading legacy "1.8" versions as 8.
+func installedByFeatureRelease(jdks []discovery.JDK) map[uint64][]string {
+	installed := make(map[uint64][]string)
+	for _, jdk := range jdks {
+		v, err := semver.ParseVersion(jdk.Identifier)
+		if err != nil {
+			v, err = semver.ParseVersion(jdk.Version)
+		}
+		if err != nil {
+			continue
+		}
+		feature := v.Major()
+		if !slices.Contains(installed[feature], jdk.Identifier) {
+			installed[feature] = append(installed[feature], jdk.Identifier)
+		}
+	}
+	for _, identifiers := range installed {
+		slices.Sort(identifiers)
+	}
+	return installed
+}
+
+// newestGA returns the newest generally available build in versions.
+func newestGA(versions []string) string {
+	var newest *semver.Version
+	for _, raw := range versions {
+		v, err := semver.ParseVersion(raw)
+		if err != nil || v.Prerelease() != "" {
+			continue
+		}
+		if newest == nil || newest.LessThan(v) {
+			newest = v
+		}
+	}
+	if newest == nil {
+		return ""
+	}
+	return newest.String()
+}
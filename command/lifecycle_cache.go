@@ -0,0 +1,142 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/discoapi"
+	"github.com/felipebz/javm/discovery"
+	"github.com/felipebz/javm/semver"
+)
+
+// lifecycleCache keeps the DiscoAPI major version data used to warn about
+// outdated JDKs, so that `use` and `which` never wait for the network.
+type lifecycleCache struct {
+	Fetched       time.Time               `json:"fetched"`
+	MajorVersions []discoapi.MajorVersion `json:"major_versions"`
+}
+
+func lifecycleCacheFile() string {
+	return filepath.Join(cfg.Dir(), "lifecycle.json")
+}
+
+func saveLifecycleCache(majors []discoapi.MajorVersion) error {
+	return saveJSONCache(lifecycleCacheFile(), lifecycleCache{Fetched: time.Now().UTC(), MajorVersions: majors})
+}
+
+// loadLifecycleCache returns nil when nothing has been cached yet.
+func loadLifecycleCache() (*lifecycleCache, error) {
+	var cache lifecycleCache
+	if found, err := loadJSONCache(lifecycleCacheFile(), &cache); !found || err != nil {
+		return nil, err
+	}
+	return &cache, nil
+}
+
+// lifecycleCacheTTL is how long cached major version data is considered fresh.
+const lifecycleCacheTTL = 24 * time.Hour
+
+// refreshLifecycleCache updates a missing or stale cache unless client is
+// nil. Failures only cost the freshness of later warnings.
+func refreshLifecycleCache(ctx context.Context, client MajorVersionsClient) {
+	if client == nil {
+		return
+	}
+	if cache, err := loadLifecycleCache(); err == nil && cache != nil && time.Since(cache.Fetched) < lifecycleCacheTTL {
+		return
+	}
+	majors, err := client.GetMajorVersionsContext(ctx)
+	if err == nil {
+		err = saveLifecycleCache(majors)
+	}
+	if err != nil {
+		loggerFromContext(ctx).Debug("Could not refresh lifecycle cache: ", err)
+	}
+}
+
+var errOutdatedJDK = errors.New("outdated JDK")
+
+// checkLifecycle applies lifecycle.policy to jdk using cached DiscoAPI data.
+// It returns an error only when the policy is "error" and the JDK is end of
+// life or more than lifecycle.max_patches_behind releases behind.
+func checkLifecycle(ctx context.Context, jdk discovery.JDK) error {
+	policy, err := cfg.EffectiveValue("lifecycle.policy")
+	if err != nil || policy == "off" {
+		return nil
+	}
+	cache, err := loadLifecycleCache()
+	if err != nil {
+		loggerFromContext(ctx).Debug("Skipping lifecycle check: ", err)
+		return nil
+	}
+	if cache == nil {
+		return nil
+	}
+	maxBehind := 3
+	if value, err := cfg.EffectiveValue("lifecycle.max_patches_behind"); err == nil {
+		if n, err := strconv.Atoi(value); err == nil {
+			maxBehind = n
+		}
+	}
+
+	problem := lifecycleProblem(jdk, cache.MajorVersions, maxBehind)
+	if problem == "" {
+		return nil
+	}
+	if policy == "error" {
+		return fmt.Errorf("%w: %s (lifecycle.policy is error)", errOutdatedJDK, problem)
+	}
+	loggerFromContext(ctx).Warn(problem)
+	return nil
+}
+
+// lifecycleProblem describes why jdk should be upgraded, or returns an empty
+// string when it is current enough or its release line is unknown.
+func lifecycleProblem(jdk discovery.JDK, majors []discoapi.MajorVersion, maxBehind int) string {
+	v, err := semver.ParseVersion(jdk.Version)
+	if err != nil {
+		_, version, _ := strings.Cut(jdk.Identifier, "@")
+		if v, err = semver.ParseVersion(version); err != nil {
+			return ""
+		}
+	}
+	feature := v.Major()
+	for _, major := range majors {
+		if uint64(major.MajorVersion) != feature {
+			continue
+		}
+		if !major.Maintained {
+			return fmt.Sprintf("%s is end of life: Java %d is no longer maintained", jdk.Identifier, feature)
+		}
+		newer := newerPatchReleases(v, major.Versions)
+		if len(newer) > maxBehind {
+			return fmt.Sprintf("%s is %d patch releases behind Java %s", jdk.Identifier, len(newer), newestGA(major.Versions))
+		}
+		return ""
+	}
+	return ""
+}
+
+// newerPatchReleases lists the GA releases in versions newer than v, counting
+// several builds of the same release once.
+func newerPatchReleases(v *semver.Version, versions []string) []string {
+	var newer []string
+	seen := make(map[string]bool)
+	for _, raw := range versions {
+		candidate, err := semver.ParseVersion(raw)
+		if err != nil || candidate.Prerelease() != "" || v.CompareRelease(candidate) >= 0 {
+			continue
+		}
+		if release := candidate.Release(); !seen[release] {
+			seen[release] = true
+			newer = append(newer, release)
+		}
+	}
+	return newer
+}
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/discoapi"
+	"github.com/felipebz/javm/discovery"
+	log "github.com/sirupsen/logrus"
+)
+
+var lifecycleTestMajors = []discoapi.MajorVersion{
+	{MajorVersion: 21, TermOfSupport: "LTS", Maintained: true,
+		Versions: []string{"21.0.4+7", "21.0.3+9", "21.0.3+7", "21.0.2+13", "21.0.1+12", "21+35"}},
+	{MajorVersion: 15, TermOfSupport: "STS", Versions: []string{"15.0.2+7"}},
+	{MajorVersion: 8, TermOfSupport: "LTS", Maintained: true,
+		Versions: []string{"8.0.422+5", "8.0.412+8", "8.0.402+6", "8.0.392+8", "8.0.382+5"}},
+}
+
+func TestLifecycleProblem(t *testing.T) {
+	tests := []struct {
+		name string
+		jdk  discovery.JDK
+		want string
+	}{
+		{"current", discovery.JDK{Identifier: "temurin@21.0.4", Version: "21.0.4"}, ""},
+		{"within threshold", discovery.JDK{Identifier: "temurin@21.0.1", Version: "21.0.1"}, ""},
+		{"behind", discovery.JDK{Identifier: "temurin@21.0.0", Version: "21"}, "temurin@21.0.0 is 4 patch releases behind Java 21.0.4+7"},
+		{"version from identifier", discovery.JDK{Identifier: "temurin@21.0.0"}, "temurin@21.0.0 is 4 patch releases behind Java 21.0.4+7"},
+		{"end of life", discovery.JDK{Identifier: "zulu@15.0.2", Version: "15.0.2"}, "zulu@15.0.2 is end of life: Java 15 is no longer maintained"},
+		{"legacy version", discovery.JDK{Identifier: "zulu@1.8.0_382", Version: "1.8.0_382"}, "zulu@1.8.0_382 is 4 patch releases behind Java 8.0.422+5"},
+		{"legacy identifier", discovery.JDK{Identifier: "zulu@1.8.402"}, ""},
+		{"unknown line", discovery.JDK{Identifier: "zulu@17.0.2", Version: "17.0.2"}, ""},
+	}
+	for _, tt := range tests {
+		if got := lifecycleProblem(tt.jdk, lifecycleTestMajors, 3); got != tt.want {
+			t.Errorf("%s: lifecycleProblem() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckLifecycleAppliesPolicy(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	eol := discovery.JDK{Identifier: "zulu@15.0.2", Version: "15.0.2"}
+
+	var logs bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&logs)
+	ctx := WithRuntime(context.Background(), Runtime{Logger: logger})
+
+	if err := checkLifecycle(ctx, eol); err != nil || logs.Len() != 0 {
+		t.Fatalf("without cached data: err = %v, logs = %q", err, logs.String())
+	}
+	if err := saveLifecycleCache(lifecycleTestMajors); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := checkLifecycle(ctx, eol); err != nil {
+		t.Fatalf("warn policy returned an error: %v", err)
+	}
+	if !strings.Contains(logs.String(), "end of life") || strings.Count(strings.TrimSpace(logs.String()), "\n") != 0 {
+		t.Fatalf("expected a single warning line, got %q", logs.String())
+	}
+
+	if err := cfg.SetValue("lifecycle.policy", "error"); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkLifecycle(ctx, eol); !errors.Is(err, errOutdatedJDK) {
+		t.Fatalf("error policy: expected errOutdatedJDK, got %v", err)
+	}
+
+	if err := cfg.SetValue("lifecycle.policy", "off"); err != nil {
+		t.Fatal(err)
+	}
+	logs.Reset()
+	if err := checkLifecycle(ctx, eol); err != nil || logs.Len() != 0 {
+		t.Fatalf("off policy: err = %v, logs = %q", err, logs.String())
+	}
+}
+
+func TestRefreshLifecycleCacheOnlyWhenStale(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	client := &mockMajorVersionsClient{majors: lifecycleTestMajors}
+	refreshLifecycleCache(context.Background(), client)
+	cache, err := loadLifecycleCache()
+	if err != nil || cache == nil || len(cache.MajorVersions) != len(lifecycleTestMajors) {
+		t.Fatalf("cache was not written: %+v, %v", cache, err)
+	}
+
+	client.majors = nil
+	refreshLifecycleCache(context.Background(), client)
+	if cache, _ := loadLifecycleCache(); len(cache.MajorVersions) != len(lifecycleTestMajors) {
+		t.Fatalf("fresh cache was replaced")
+	}
+
+	// Without a client, the cache is left as it is.
+	refreshLifecycleCache(context.Background(), nil)
+}
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/felipebz/javm/discoapi"
+	"github.com/felipebz/javm/discovery"
+)
+
+type mockMajorVersionsClient struct {
+	majors []discoapi.MajorVersion
+	err    error
+}
+
+func (m *mockMajorVersionsClient) GetMajorVersionsContext(ctx context.Context) ([]discoapi.MajorVersion, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.majors, m.err
+}
+
+func TestNewLifecycleCommand(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	cleanup := setupMockLs()
+	defer cleanup()
+	mockLsResult = []discovery.JDK{
+		{Identifier: "temurin@21.0.2", Version: "21.0.2", Source: "javm"},
+		{Identifier: "zulu@21.0.1", Version: "21.0.1", Source: "javm"},
+		{Identifier: "system@1.8.0", Version: "1.8.0", Source: "system"},
+	}
+	client := &mockMajorVersionsClient{majors: []discoapi.MajorVersion{
+		{MajorVersion: 8, TermOfSupport: "LTS", Maintained: true, Versions: []string{"8.0.422+5"}},
+		{MajorVersion: 22, TermOfSupport: "STS", Versions: []string{"22.0.2+9", "22.0.1+8"}},
+		{MajorVersion: 23, TermOfSupport: "STS", Maintained: true, Versions: []string{"23-ea+30", "23.0.1+11", "23+37"}},
+		{MajorVersion: 21, TermOfSupport: "LTS", Maintained: true, Versions: []string{"21.0.3+9", "21.0.4+7"}},
+	}}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "maintained and installed",
+			want: `MAJOR   SUPPORT   STATUS       LATEST GA   INSTALLED
+23      STS       maintained   23.0.1+11   -
+21      LTS       maintained   21.0.4+7    temurin@21.0.2, zulu@21.0.1
+8       LTS       maintained   8.0.422+5   system@1.8.0
+`,
+		},
+		{
+			name: "all",
+			args: []string{"--all"},
+			want: `MAJOR   SUPPORT   STATUS        LATEST GA   INSTALLED
+23      STS       maintained    23.0.1+11   -
+22      STS       end of life   22.0.2+9    -
+21      LTS       maintained    21.0.4+7    temurin@21.0.2, zulu@21.0.1
+8       LTS       maintained    8.0.422+5   system@1.8.0
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewLifecycleCommand(client)
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetArgs(tt.args)
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLifecycleCommandReportsNetworkErrors(t *testing.T) {
+	cmd := NewLifecycleCommand(&mockMajorVersionsClient{err: errors.New("unreachable")})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(nil)
+	if err := cmd.Execute(); !errors.Is(err, ErrNetwork) {
+		t.Fatalf("expected network error, got %v", err)
+	}
+}
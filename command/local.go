@@ -0,0 +1,115 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/discovery"
+	"github.com/felipebz/javm/semver"
+	"github.com/spf13/cobra"
+)
+
+func NewLocalCommand(client CatalogClient) *cobra.Command {
+	var exact, keepRange, unset bool
+	cmd := &cobra.Command{
+		Use:   "local [version]",
+		Short: "Set or show the Java version of the current directory",
+		Long: "Write version to the .java-version file of the current directory, after checking\n" +
+			"that it selects an installed JDK or one that can be installed. Without a version,\n" +
+			"print the effective pin and the file it comes from.",
+		Args: UsageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch {
+			case exact && keepRange:
+				return UsageError(errors.New("--pin and --range cannot be combined"))
+			case unset && (len(args) != 0 || exact || keepRange):
+				return UsageError(errors.New("--unset takes no version"))
+			case unset:
+				return unsetLocal(cmd.Context())
+			case len(args) == 0 && (exact || keepRange):
+				return UsageError(errors.New("--pin and --range need a version"))
+			case len(args) == 0:
+				selector, path := cfg.LookupJavaVersion()
+				if selector == "" {
+					return NotFoundError(errors.New("no Java version is pinned for the current directory"))
+				}
+				if _, err := fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", selector, path); err != nil {
+					return fmt.Errorf("write pin: %w", err)
+				}
+				return nil
+			}
+			selector, err := pin(cmd.Context(), client, args[0], exact)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Pinned Java version %s in %s\n", selector, cfg.JavaVersionFile); err != nil {
+				return fmt.Errorf("write pin confirmation: %w", err)
+			}
+			return nil
+		},
+		Example: "  javm local temurin@21\n" +
+			"  javm local 21 --pin   # write the exact version, such as temurin@21.0.2+13\n" +
+			"  javm local \"temurin|zulu@21\" --range # write the selector as given\n" +
+			"  javm local --unset",
+	}
+	cmd.Flags().BoolVar(&exact, "pin", false, "write the exact version the selector resolves to")
+	cmd.Flags().BoolVar(&keepRange, "range", false, "write the selector as given, keeping its range (the default)")
+	cmd.Flags().BoolVar(&unset, "unset", false, "remove the .java-version file of the current directory")
+	return cmd
+}
+
+// resolveLocalSelector returns the version selector resolves to, looking at
+// the installed JDKs first and then at the releases DiscoAPI lists for this
+// platform.
+func resolveLocalSelector(ctx context.Context, client CatalogClient, selector string) (*semver.Version, error) {
+	jdk, _, err := resolveJDK(ctx, selector)
+	if err == nil {
+		return installedVersion(jdk)
+	}
+	if !errors.Is(err, ErrNotFound) || client == nil {
+		return nil, err
+	}
+	_, ver, err := resolveRemote(ctx, client, selector, hostInstallTarget())
+	if err != nil {
+		return nil, err
+	}
+	loggerFromContext(ctx).Info(selector, " isn't installed; ", ver, " can be installed with `javm install`")
+	return ver, nil
+}
+
+// installedVersion returns the version of jdk as a selector. JDKs outside
+// javm's directory carry identifiers of their own, so only their version is
+// used.
+func installedVersion(jdk discovery.JDK) (*semver.Version, error) {
+	if jdk.Source == "javm" {
+		if v, err := semver.ParseVersion(jdk.Identifier); err == nil {
+			return v, nil
+		}
+	}
+	v, err := semver.ParseVersion(jdk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("read version of %s: %w", jdk.Path, err)
+	}
+	return v, nil
+}
+
+// unsetLocal removes the .java-version file of the current directory.
+func unsetLocal(ctx context.Context) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(cwd, cfg.JavaVersionFile)
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return NotFoundError(fmt.Errorf("no %s in %s", cfg.JavaVersionFile, cwd))
+		}
+		return fmt.Errorf("remove %s: %w", cfg.JavaVersionFile, err)
+	}
+	loggerFromContext(ctx).Info("Removed ", path)
+	return nil
+}
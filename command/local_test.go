@@ -0,0 +1,103 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/felipebz/javm/discoapi"
+	"github.com/felipebz/javm/discovery"
+)
+
+func runLocal(t *testing.T, client CatalogClient, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := NewLocalCommand(client)
+	cmd.SetOut(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestLocal(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	t.Setenv("JAVM_CEILING_DIRECTORIES", "")
+	cleanup := setupMockLs()
+	defer cleanup()
+	mockLsResult = []discovery.JDK{
+		{Identifier: "temurin@21.0.2+13", Version: "21.0.2+13", Source: "javm", Path: "/tmp/jdk/temurin@21.0.2+13"},
+		{Identifier: "zulu-system@17", Version: "17.0.9", Source: "system", Path: "/usr/lib/jvm/zulu-17"},
+	}
+	client := &queryRecordingClient{packages: func(discoapi.PackageQuery) []discoapi.Package {
+		return []discoapi.Package{{Id: "22", Distribution: "temurin", JavaVersion: "22.0.2+9"}}
+	}}
+	project := t.TempDir()
+	t.Chdir(project)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"21"}, "21"},
+		{[]string{"21", "--pin"}, "temurin@21.0.2+13"},
+		{[]string{"temurin|zulu@~21.0.1", "--range"}, "temurin|zulu@~21.0.1"},
+		{[]string{"17", "--pin"}, "17.0.9"},
+		{[]string{"temurin@22", "--pin"}, "temurin@22.0.2+9"},
+	}
+	for _, tt := range tests {
+		if _, err := runLocal(t, client, tt.args...); err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		data, err := os.ReadFile(filepath.Join(project, ".java-version"))
+		if err != nil || string(data) != tt.want+"\n" {
+			t.Errorf("%v: .java-version = %q, %v; want %q", tt.args, data, err, tt.want)
+		}
+	}
+
+	out, err := runLocal(t, client)
+	if err != nil || out != "temurin@22.0.2+9\t"+filepath.Join(project, ".java-version")+"\n" {
+		t.Fatalf("show: %q, %v", out, err)
+	}
+	if _, err := runLocal(t, client, "--unset"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(project, ".java-version")); !os.IsNotExist(err) {
+		t.Fatalf(".java-version was not removed: %v", err)
+	}
+	if _, err := runLocal(t, client); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("show without a pin: got %v, want a not found error", err)
+	}
+}
+
+func TestLocalRejectsInvalidInput(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	t.Setenv("JAVM_CEILING_DIRECTORIES", "")
+	cleanup := setupMockLs()
+	defer cleanup()
+	mockLsResult = []discovery.JDK{}
+	client := &queryRecordingClient{packages: func(discoapi.PackageQuery) []discoapi.Package {
+		return []discoapi.Package{{Id: "21", Distribution: "temurin", JavaVersion: "21.0.2+13"}}
+	}}
+	t.Chdir(t.TempDir())
+	tests := []struct {
+		args []string
+		want error
+	}{
+		{[]string{"not a version"}, ErrUsage},
+		{[]string{"21", "--pin", "--range"}, ErrUsage},
+		{[]string{"21", "--unset"}, ErrUsage},
+		{[]string{"--pin"}, ErrUsage},
+		{[]string{"temurin@8"}, ErrNotFound},
+		{[]string{"--unset"}, ErrNotFound},
+	}
+	for _, tt := range tests {
+		if _, err := runLocal(t, client, tt.args...); !errors.Is(err, tt.want) {
+			t.Errorf("%v: got %v, want %v", tt.args, err, tt.want)
+		}
+	}
+	if _, err := os.Stat(".java-version"); !os.IsNotExist(err) {
+		t.Fatalf(".java-version was written: %v", err)
+	}
+}
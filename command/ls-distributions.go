@@ -1,11 +1,17 @@
 package command
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/felipebz/javm/discoapi"
+	"github.com/felipebz/javm/semver"
 	"github.com/spf13/cobra"
 )
 
@@ -13,19 +19,38 @@ type DistributionsClient interface {
 	GetDistributionsContext(ctx context.Context) ([]discoapi.Distribution, error)
 }
 
+// DistributionDetailsClient is implemented by clients that can also list the
+// versions each distribution publishes.
+type DistributionDetailsClient interface {
+	GetDistributionDetailsContext(ctx context.Context) ([]discoapi.Distribution, error)
+}
+
 func NewLsDistributionsCommand(client DistributionsClient) *cobra.Command {
-	return &cobra.Command{
+	var showDetails bool
+	cmd := &cobra.Command{
 		Use:   "ls-distributions",
 		Short: "List all available Java distributions",
 		Args:  UsageArgs(cobra.NoArgs),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			distributions, err := client.GetDistributionsContext(cmd.Context())
+			fetch := client.GetDistributionsContext
+			if detailsClient, ok := client.(DistributionDetailsClient); ok && showDetails {
+				fetch = detailsClient.GetDistributionDetailsContext
+			}
+			distributions, err := fetch(cmd.Context())
 			if err != nil {
 				return NetworkError(err)
 			}
+			if err := saveDistributionCache(distributions); err != nil {
+				loggerFromContext(cmd.Context()).Debug("Could not cache distributions: ", err)
+			}
+			if showDetails {
+				return printDistributionDetails(cmd.OutOrStdout(), distributions)
+			}
 			return printDistributions(cmd.OutOrStdout(), distributions)
 		},
 	}
+	cmd.Flags().BoolVarP(&showDetails, "details", "d", false, "Show synonyms and the major versions each distribution publishes")
+	return cmd
 }
 
 func printDistributions(w io.Writer, distributions []discoapi.Distribution) error {
@@ -39,3 +64,55 @@ func printDistributions(w io.Writer, distributions []discoapi.Distribution) erro
 	}
 	return nil
 }
+
+func printDistributionDetails(w io.Writer, distributions []discoapi.Distribution) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "IDENTIFIER\tNAME\tSYNONYMS\tVERSIONS"); err != nil {
+		return fmt.Errorf("write distribution header: %w", err)
+	}
+	for _, dist := range distributions {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			dist.APIParameter,
+			dist.Name,
+			valueOrDash(strings.Join(distinctSynonyms(dist), ", ")),
+			valueOrDash(strings.Join(publishedMajors(dist.Versions), ", ")),
+		); err != nil {
+			return fmt.Errorf("write distribution: %w", err)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush distribution output: %w", err)
+	}
+	return nil
+}
+
+// distinctSynonyms drops synonyms that only differ from the identifier, the
+// name or each other by case or separators.
+func distinctSynonyms(dist discoapi.Distribution) []string {
+	seen := []string{distributionKey(dist.APIParameter), distributionKey(dist.Name)}
+	var synonyms []string
+	for _, synonym := range dist.Synonyms {
+		if key := distributionKey(synonym); !slices.Contains(seen, key) {
+			seen = append(seen, key)
+			synonyms = append(synonyms, synonym)
+		}
+	}
+	return synonyms
+}
+
+// publishedMajors lists the major versions found in versions, newest first.
+func publishedMajors(versions []string) []string {
+	var majors []uint64
+	for _, raw := range versions {
+		v, err := semver.ParseVersion(raw)
+		if err == nil && !slices.Contains(majors, v.Major()) {
+			majors = append(majors, v.Major())
+		}
+	}
+	slices.SortFunc(majors, func(a, b uint64) int { return cmp.Compare(b, a) })
+	result := make([]string, len(majors))
+	for i, major := range majors {
+		result[i] = strconv.FormatUint(major, 10)
+	}
+	return result
+}
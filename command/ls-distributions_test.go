@@ -33,6 +33,7 @@ func TestLsDistributionsPropagatesCancellation(t *testing.T) {
 }
 
 func TestNewLsDistributionsCommand(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
 	mock := &mockClient{distributions: []discoapi.Distribution{
 		{Name: "Temurin", APIParameter: "temurin"},
 		{Name: "Zulu", APIParameter: "zulu"},
@@ -53,3 +54,34 @@ zulu                 Zulu
 		t.Errorf("unexpected output:\n%q\nwant:\n%q", got, want)
 	}
 }
+
+type mockDetailsClient struct {
+	mockClient
+}
+
+func (m *mockDetailsClient) GetDistributionDetailsContext(ctx context.Context) ([]discoapi.Distribution, error) {
+	return m.GetDistributionsContext(ctx)
+}
+
+func TestLsDistributionsDetails(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	mock := &mockDetailsClient{mockClient{distributions: []discoapi.Distribution{
+		{Name: "Temurin", APIParameter: "temurin", Synonyms: []string{"temurin", "Temurin", "adoptium", "Adoptium"},
+			Versions: []string{"21.0.4+7", "21.0.3+9", "17.0.12+7", "8.0.422+5"}},
+		{Name: "Zulu", APIParameter: "zulu"},
+	}}}
+	cmd := NewLsDistributionsCommand(mock)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--details"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `IDENTIFIER   NAME      SYNONYMS   VERSIONS
+temurin      Temurin   adoptium   21, 17, 8
+zulu         Zulu      -          -
+`
+	if out.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
@@ -5,18 +5,30 @@ import (
 	"fmt"
 	"io"
 	"runtime"
+	"slices"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/discoapi"
 	"github.com/felipebz/javm/semver"
 	"github.com/spf13/cobra"
 )
 
-func NewLsRemoteCommand(client PackagesClient) *cobra.Command {
-	var trimTo string
-	var osFlag string
-	var archFlag string
-	var distributionFlag string
+type lsRemoteOptions struct {
+	os           string
+	arch         string
+	libc         string
+	distribution string
+	trimTo       string
+	rangeArg     string
+	details      bool
+	ltsOnly      bool
+	latestBuilds bool
+}
+
+func NewLsRemoteCommand(client CatalogClient) *cobra.Command {
+	var opts lsRemoteOptions
 
 	defaultDistribution, _ := cfg.EffectiveValue("java.default_distribution")
 
@@ -25,71 +37,104 @@ func NewLsRemoteCommand(client PackagesClient) *cobra.Command {
 		Short: "List remote versions available for install",
 		Args:  UsageArgs(cobra.MaximumNArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			rangeArg := ""
 			if len(args) > 0 {
-				rangeArg = args[0]
+				opts.rangeArg = args[0]
+			}
+			if err := validateLibC(opts.libc); err != nil {
+				return err
 			}
 
-			normalizedOS := normalizeOS(osFlag)
-			return runLsRemote(
-				cmd.Context(),
-				cmd.OutOrStdout(),
-				client,
-				normalizedOS,
-				archFlag,
-				distributionFlag,
-				trimTo,
-				rangeArg,
-			)
+			opts.os = normalizeOS(opts.os)
+			return runLsRemote(cmd.Context(), cmd.OutOrStdout(), client, opts)
 		},
 	}
-	cmd.Flags().StringVar(&osFlag, "os", runtime.GOOS, "Operating System (macos, linux, windows)")
-	cmd.Flags().StringVar(&archFlag, "arch", runtime.GOARCH, "Architecture (amd64, arm64)")
-	cmd.Flags().StringVar(&distributionFlag, "distribution", defaultDistribution, "Java distribution (e.g. temurin, zulu, corretto). Use \"all\" to list all distributions")
-	cmd.Flags().StringVar(&trimTo, "latest", "major",
+	cmd.Flags().StringVar(&opts.os, "os", runtime.GOOS, "Operating System (macos, linux, windows)")
+	cmd.Flags().StringVar(&opts.arch, "arch", runtime.GOARCH, "Architecture (amd64, arm64, 386, arm, armv6, ppc64le, s390x, riscv64)")
+	cmd.Flags().StringVar(&opts.libc, "libc", libcFlagValue(), "C library of Linux JDKs (auto, glibc, musl); auto means glibc when --os or --arch is not the host")
+	cmd.Flags().StringVar(&opts.distribution, "distribution", defaultDistribution, "Java distribution (e.g. temurin, zulu, corretto). Use \"all\" to list all distributions")
+	cmd.Flags().StringVar(&opts.trimTo, "latest", "major",
 		"Part of the version to trim to (\"major\", \"minor\" or \"patch\")")
+	cmd.Flags().BoolVarP(&opts.details, "details", "d", false, "Show support term, archive type, size and release date")
+	cmd.Flags().BoolVar(&opts.ltsOnly, "lts", false, "Only list long-term support releases")
+	cmd.Flags().BoolVar(&opts.latestBuilds, "latest-build-only", false, "Only list packages that are the latest build of their release")
 	return cmd
 }
 
-func runLsRemote(
-	ctx context.Context,
-	out io.Writer,
-	client PackagesClient,
-	osFlag, archFlag, distributionFlag, trimTo, rangeArg string,
-) error {
+func runLsRemote(ctx context.Context, out io.Writer, client CatalogClient, opts lsRemoteOptions) error {
 	var r *semver.Range
 	var err error
-	if rangeArg != "" {
-		r, err = semver.ParseRange(rangeArg)
+	if opts.rangeArg != "" {
+		r, err = semver.ParseRange(opts.rangeArg)
 		if err != nil {
 			return UsageError(err)
 		}
 	}
 
-	if distributionFlag == "all" {
-		distributionFlag = ""
+	distribution := opts.distribution
+	if distribution == "all" {
+		distribution = ""
+	}
+	if distribution != "" || (r != nil && r.Qualifier != "") {
+		distributions := knownDistributions(ctx, client)
+		if distribution, err = canonicalizeDistribution(ctx, distribution, distributions); err != nil {
+			return err
+		}
+		if r != nil && r.Qualifier != "" {
+			if r, err = mapQualifiers(r, func(name string) (string, error) {
+				return canonicalizeDistribution(ctx, name, distributions)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	query := discoapi.PackageQuery{
+		OS:           opts.os,
+		Arch:         opts.arch,
+		Distribution: distribution,
+		LibC:         newInstallTarget(opts.os, opts.arch, opts.libc).libc,
+		ArchiveTypes: archiveTypes(),
+	}
+	if r != nil {
+		if major, ok := r.Major(); ok && major > 1 {
+			query.JDKVersion = major
+		}
 	}
-	packageIndex, err := makePackageIndex(ctx, client, osFlag, archFlag, distributionFlag)
+	packageIndex, err := makePackageIndex(ctx, client, query)
 	if err != nil {
 		return err
 	}
+	if r != nil {
+		if r, err = resolveRange(ctx, r, remoteReleases(packageIndex)); err != nil {
+			return err
+		}
+	}
 
+	trimTo := opts.trimTo
 	trimToValue := parseTrimTo(trimTo)
 	if trimTo != "" && trimToValue < 0 {
 		return UsageError(fmt.Errorf("invalid value for --latest %q: want major, minor or patch", trimTo))
 	}
 	vs := packageIndex.Sorted
+	if opts.ltsOnly || opts.latestBuilds {
+		vs = slices.DeleteFunc(slices.Clone(vs), func(v *semver.Version) bool {
+			pkg := packageIndex.ByVersion[v]
+			return (opts.ltsOnly && !pkg.IsLTS()) || (opts.latestBuilds && !pkg.LatestBuildAvailable)
+		})
+	}
 	if trimTo != "" {
 		vs = semver.VersionSlice(vs).TrimTo(trimToValue)
 	}
 
+	if opts.details {
+		return printVersionDetails(out, vs, packageIndex, r, trimToValue)
+	}
 	return printVersions(out, vs, packageIndex, r, trimToValue)
 }
 
 func printVersions(out io.Writer, versions []*semver.Version, packageIndex *packageIndex, r *semver.Range, value semver.VersionPart) error {
 	headerPrinted := false
 	for _, v := range versions {
-		if r != nil && !r.Contains(v) {
+		if r != nil && !packageMatches(r, v, packageIndex.ByVersion[v]) {
 			continue
 		}
 		pkg := packageIndex.ByVersion[v]
@@ -108,6 +153,77 @@ func printVersions(out io.Writer, versions []*semver.Version, packageIndex *pack
 	return nil
 }
 
+func printVersionDetails(out io.Writer, versions []*semver.Version, packageIndex *packageIndex, r *semver.Range, value semver.VersionPart) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	headerPrinted := false
+	for _, v := range versions {
+		if r != nil && !packageMatches(r, v, packageIndex.ByVersion[v]) {
+			continue
+		}
+		pkg := packageIndex.ByVersion[v]
+
+		if !headerPrinted {
+			if _, err := fmt.Fprintln(tw, "IDENTIFIER\tJAVA VERSION\tDISTRIBUTION VERSION\tSUPPORT\tARCHIVE\tSIZE\tRELEASED"); err != nil {
+				return fmt.Errorf("write remote JDK header: %w", err)
+			}
+			headerPrinted = true
+		}
+
+		support := strings.ToUpper(pkg.TermOfSupport)
+		if support == "" {
+			support = "-"
+		}
+		if pkg.JavaFXBundled {
+			support += " +FX"
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			v.TrimTo(value),
+			pkg.JavaVersion,
+			pkg.DistributionVersion,
+			support,
+			valueOrDash(pkg.ArchiveType),
+			formatSize(pkg.Size),
+			valueOrDash(releaseDate(pkg.ReleaseDate)),
+		); err != nil {
+			return fmt.Errorf("write remote JDK: %w", err)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush remote JDK output: %w", err)
+	}
+	return nil
+}
+
+// formatSize renders a byte count using binary units, e.g. "187.4 MiB".
+func formatSize(size int64) string {
+	if size <= 0 {
+		return "-"
+	}
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// releaseDate drops the time of day from DiscoAPI timestamps.
+func releaseDate(timestamp string) string {
+	date, _, _ := strings.Cut(timestamp, "T")
+	return date
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
 func normalizeOS(os string) string {
 	switch strings.ToLower(os) {
 	case "macos", "osx", "mac", "macosx":
@@ -12,17 +12,23 @@ import (
 // --- Mock implementation ---
 
 type mockPackagesClient struct {
-	Pkgs []discoapi.Package
-	Err  error
+	Pkgs    []discoapi.Package
+	Err     error
+	Queries []discoapi.PackageQuery
 }
 
-func (m *mockPackagesClient) GetPackagesContext(ctx context.Context, os, arch, distribution, version string) ([]discoapi.Package, error) {
+func (m *mockPackagesClient) GetPackagesContext(ctx context.Context, query discoapi.PackageQuery) ([]discoapi.Package, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	m.Queries = append(m.Queries, query)
 	return m.Pkgs, m.Err
 }
 
+func (m *mockPackagesClient) GetDistributionsContext(context.Context) ([]discoapi.Distribution, error) {
+	return nil, errors.New("no distribution list")
+}
+
 func TestNewLsRemoteCommand_DefaultAndFlags(t *testing.T) {
 	mock := &mockPackagesClient{
 		Pkgs: []discoapi.Package{
@@ -106,7 +112,7 @@ func TestRunLsRemote_EmptyResult(t *testing.T) {
 		Pkgs: []discoapi.Package{},
 	}
 	var out bytes.Buffer
-	err := runLsRemote(context.Background(), &out, mock, "linux", "amd64", "", "major", "")
+	err := runLsRemote(context.Background(), &out, mock, lsRemoteOptions{os: "linux", arch: "amd64", trimTo: "major"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -124,7 +130,7 @@ func TestRunLsRemote_SpecificDistribution(t *testing.T) {
 		},
 	}
 	var out bytes.Buffer
-	err := runLsRemote(context.Background(), &out, mock, "linux", "amd64", "zulu", "patch", "")
+	err := runLsRemote(context.Background(), &out, mock, lsRemoteOptions{os: "linux", arch: "amd64", distribution: "zulu", trimTo: "patch"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -146,7 +152,7 @@ func TestRunLsRemote_WithRange(t *testing.T) {
 		},
 	}
 	var out bytes.Buffer
-	err := runLsRemote(context.Background(), &out, mock, "linux", "amd64", "", "patch", ">=20")
+	err := runLsRemote(context.Background(), &out, mock, lsRemoteOptions{os: "linux", arch: "amd64", trimTo: "patch", rangeArg: ">=20"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -169,3 +175,100 @@ func TestLsRemotePropagatesCancellation(t *testing.T) {
 		t.Fatalf("expected cancellation error, got %v", err)
 	}
 }
+
+func TestLsRemoteLibCFlag(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	mock := &mockPackagesClient{}
+	cmd := NewLsRemoteCommand(mock)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--os=linux", "--libc=musl"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mock.Queries) != 1 || mock.Queries[0].LibC != "musl" {
+		t.Fatalf("queries = %+v, want libc musl", mock.Queries)
+	}
+
+	cmd = NewLsRemoteCommand(mock)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--libc=bionic"})
+	if err := cmd.Execute(); !errors.Is(err, ErrUsage) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func TestLsRemoteDetailsAndFilters(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	mock := &mockPackagesClient{
+		Pkgs: []discoapi.Package{
+			{JavaVersion: "21.0.2+13", Distribution: "temurin", DistributionVersion: "21.0.2", TermOfSupport: "lts",
+				ArchiveType: "tar.gz", Size: 206126467, ReleaseDate: "2024-01-16T00:00:00Z", LatestBuildAvailable: true},
+			{JavaVersion: "21.0.1+12", Distribution: "temurin", DistributionVersion: "21.0.1", TermOfSupport: "lts"},
+			{JavaVersion: "22.0.1+8", Distribution: "temurin", DistributionVersion: "22.0.1", TermOfSupport: "sts",
+				ArchiveType: "zip", JavaFXBundled: true, LatestBuildAvailable: true},
+		},
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "details",
+			args: []string{"--details", "--latest=patch"},
+			want: `IDENTIFIER       JAVA VERSION   DISTRIBUTION VERSION   SUPPORT   ARCHIVE   SIZE        RELEASED
+temurin@21.0.1   21.0.1+12      21.0.1                 LTS       -         -           -
+temurin@21.0.2   21.0.2+13      21.0.2                 LTS       tar.gz    196.6 MiB   2024-01-16
+temurin@22.0.1   22.0.1+8       22.0.1                 STS +FX   zip       -           -
+`,
+		},
+		{
+			name: "lts",
+			args: []string{"--lts", "--latest=patch"},
+			want: `Identifier           Full Version    Distribution Version
+temurin@21.0.1       21.0.1+12       temurin 21.0.1
+temurin@21.0.2       21.0.2+13       temurin 21.0.2
+`,
+		},
+		{
+			name: "latest build only",
+			args: []string{"--latest-build-only", "--latest=patch"},
+			want: `Identifier           Full Version    Distribution Version
+temurin@21.0.2       21.0.2+13       temurin 21.0.2
+temurin@22.0.1       22.0.1+8        temurin 22.0.1
+`,
+		},
+		{
+			name: "lts before trimming",
+			args: []string{"--lts"},
+			want: `Identifier           Full Version    Distribution Version
+temurin@21           21.0.2+13       temurin 21.0.2
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewLsRemoteCommand(mock)
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetArgs(tt.args)
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{0: "-", 512: "512 B", 2048: "2.0 KiB", 206126467: "196.6 MiB", 3 << 30: "3.0 GiB"}
+	for size, want := range tests {
+		if got := formatSize(size); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package command
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"sort"
 	"text/tabwriter"
 
@@ -34,6 +36,11 @@ func NewLsCommand() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if rng != nil {
+				if rng, err = resolveRange(cmd.Context(), rng, localReleases(jdks, rng)); err != nil {
+					return err
+				}
+			}
 
 			return printInstalledVersions(cmd.OutOrStdout(), jdks, rng, showDetails)
 		},
@@ -76,7 +83,7 @@ func LsBestMatchContext(ctx context.Context, selector string, managedOnly bool)
 	if err != nil {
 		return "", err
 	}
-	jdk, err := FindBestMatchJDK(jdks, selector)
+	jdk, err := FindBestMatchJDKContext(ctx, jdks, selector)
 	if err != nil {
 		return "", err
 	}
@@ -84,10 +91,32 @@ func LsBestMatchContext(ctx context.Context, selector string, managedOnly bool)
 }
 
 func FindBestMatchJDK(jdks []discovery.JDK, selector string) (discovery.JDK, error) {
+	return FindBestMatchJDKContext(context.Background(), jdks, selector)
+}
+
+// FindBestMatchJDKContext picks the newest JDK matching selector, preferring
+// JDKs managed by javm. Symbolic selectors are resolved against jdks alone, so
+// no network access is needed.
+func FindBestMatchJDKContext(ctx context.Context, jdks []discovery.JDK, selector string) (discovery.JDK, error) {
 	rng, err := semver.ParseRange(selector)
 	if err != nil {
 		return discovery.JDK{}, UsageError(err)
 	}
+	if rng.Qualifier != "" {
+		// Only the cache is consulted: local lookups never touch the network,
+		// and qualifiers such as "system" are not DiscoAPI distributions.
+		distributions := knownDistributions(ctx, nil)
+		rng, _ = mapQualifiers(rng, func(name string) (string, error) {
+			if canonical, ok := canonicalDistribution(name, distributions); ok {
+				return canonical, nil
+			}
+			return name, nil
+		})
+	}
+	symbolic := rng.Symbol() != ""
+	if rng, err = resolveRange(ctx, rng, localReleases(jdks, rng)); err != nil {
+		return discovery.JDK{}, NotFoundError(fmt.Errorf("%s isn't installed", selector))
+	}
 
 	sort.Slice(jdks, func(i, j int) bool {
 		v1, err1 := semver.ParseVersion(jdks[i].Version)
@@ -98,32 +127,58 @@ func FindBestMatchJDK(jdks []discovery.JDK, selector string) (discovery.JDK, err
 		return jdks[i].Version > jdks[j].Version
 	})
 
-	var fallback discovery.JDK
-	hasFallback := false
-
+	type match struct {
+		jdk  discovery.JDK
+		rank int
+	}
+	var matches []match
 	for _, jdk := range jdks {
 		v, err := semver.ParseVersion(jdk.Identifier)
 		if err != nil {
 			v, err = semver.ParseVersion(jdk.Version)
 		}
 
-		if err == nil && rng.Contains(v) {
-			if jdk.Source == "javm" {
-				return jdk, nil
-			}
-
-			if !hasFallback {
-				fallback = jdk
-				hasFallback = true
+		if err == nil && jdkMatches(rng, v, jdk) {
+			matches = append(matches, match{jdk, rng.QualifierRank(v)})
+		}
+	}
+	if len(matches) == 0 {
+		// JDKs installed before identifiers carried build numbers, such as
+		// "temurin@21.0.2", are still selected as "temurin@21.0.2+13".
+		if ver, err := semver.ParseVersion(selector); err == nil {
+			if _, ok := ver.Build(); ok {
+				if jdk, ok := installedAs(ctx, jdks, ver); ok {
+					return jdk, nil
+				}
 			}
 		}
+		return discovery.JDK{}, NotFoundError(fmt.Errorf("%s isn't installed", rng))
 	}
 
-	if hasFallback {
-		return fallback, nil
+	// Distributions listed first in the selector win, then the configured
+	// preferences, then the newest version.
+	preference := loadJDKPreference(ctx)
+	best := slices.MinFunc(matches, func(a, b match) int {
+		return cmp.Or(cmp.Compare(a.rank, b.rank), preference.compare(a.jdk, b.jdk))
+	})
+	return logSymbolicMatch(ctx, symbolic, selector, best.jdk), nil
+}
+
+// jdkMatches reports whether the installed jdk, of version v, is selected by
+// rng. "dist:" selectors compare the vendor version instead of v.
+func jdkMatches(rng *semver.Range, v *semver.Version, jdk discovery.JDK) bool {
+	if _, ok := rng.DistributionVersion(); ok {
+		return rng.MatchesQualifier(v) && rng.MatchesDistributionVersion(localDistributionVersion(jdk))
 	}
+	return rng.Contains(v)
+}
 
-	return discovery.JDK{}, NotFoundError(fmt.Errorf("%s isn't installed", rng))
+// logSymbolicMatch explains which JDK a symbolic selector such as "lts" picked.
+func logSymbolicMatch(ctx context.Context, symbolic bool, selector string, jdk discovery.JDK) discovery.JDK {
+	if symbolic {
+		loggerFromContext(ctx).Infof("%s resolved to %s", selector, jdk.Identifier)
+	}
+	return jdk
 }
 
 func printInstalledVersions(w io.Writer, jdks []discovery.JDK, rng *semver.Range, showDetails bool) error {
@@ -132,7 +187,7 @@ func printInstalledVersions(w io.Writer, jdks []discovery.JDK, rng *semver.Range
 	for _, jdk := range jdks {
 		if rng != nil {
 			v, err := semver.ParseVersion(jdk.Identifier)
-			if err != nil || !rng.Contains(v) {
+			if err != nil || !jdkMatches(rng, v, jdk) {
 				continue
 			}
 		}
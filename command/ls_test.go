@@ -72,6 +72,9 @@ func TestLsBestMatch(t *testing.T) {
 		{Identifier: "temurin@17.0.1", Version: "17.0.1", Source: "javm"},
 		{Identifier: "system@21", Version: "21.0.0", Source: "system"},
 		{Identifier: "temurin@8.0.352", Version: "1.8.0_352", Source: "javm"},
+		{Identifier: "zulu@1.8.0_392", Version: "1.8.0_392", Source: "jabba"},
+		{Identifier: "temurin@11.0.22", Version: "11.0.22+7", Source: "javm"},
+		{Identifier: "zulu@11.0.21+9", Version: "11.0.21+9", Source: "javm"},
 	}
 
 	tests := []struct {
@@ -82,6 +85,13 @@ func TestLsBestMatch(t *testing.T) {
 		{"17", "temurin@17.0.1", false},
 		{"21", "system@21", false},
 		{"8", "temurin@8.0.352", false},
+		{"1.8", "temurin@8.0.352", false},
+		{"temurin@1.8.0_352", "temurin@8.0.352", false},
+		{"zulu@8.0.392", "zulu@1.8.0_392", false},
+		{"zulu@1.8", "zulu@1.8.0_392", false},
+		{"temurin@11.0.22+7", "temurin@11.0.22", false},
+		{"zulu@11.0.21", "zulu@11.0.21+9", false},
+		{"zulu@11.0.21+7", "", true},
 		{"30", "", true},
 	}
 
@@ -97,6 +107,82 @@ func TestLsBestMatch(t *testing.T) {
 	}
 }
 
+func TestFindBestMatchJDKResolvesSymbolicSelectorsLocally(t *testing.T) {
+	jdks := []discovery.JDK{
+		{Identifier: "temurin@23.0.1", Version: "23.0.1", Source: "javm"},
+		{Identifier: "temurin@21.0.2", Version: "21.0.2", Source: "javm"},
+		{Identifier: "temurin@17.0.9", Version: "17.0.9", Source: "javm"},
+		{Identifier: "zulu@22.0.1", Version: "22.0.1", Source: "javm", LTS: true},
+		{Identifier: "zulu@11.0.20", Version: "11.0.20", Source: "javm"},
+	}
+	tests := []struct {
+		selector string
+		want     string
+		wantErr  bool
+	}{
+		{"latest", "temurin@23.0.1", false},
+		{"temurin@lts", "temurin@21.0.2", false},
+		{"temurin@previous-lts", "temurin@17.0.9", false},
+		{"zulu@latest-lts", "zulu@22.0.1", false},
+		{"zulu@2-lts", "zulu@11.0.20", false},
+		{"temurin@3-lts", "", true},
+	}
+	for _, tt := range tests {
+		var logs bytes.Buffer
+		logger := log.New()
+		logger.SetOutput(&logs)
+		ctx := WithRuntime(context.Background(), Runtime{Logger: logger})
+
+		got, err := FindBestMatchJDKContext(ctx, jdks, tt.selector)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("FindBestMatchJDKContext(%q) error = %v, wantErr %v", tt.selector, err, tt.wantErr)
+			continue
+		}
+		if got.Identifier != tt.want {
+			t.Errorf("FindBestMatchJDKContext(%q) = %v, want %v", tt.selector, got.Identifier, tt.want)
+		}
+		if !tt.wantErr && !strings.Contains(logs.String(), tt.selector+" resolved to "+tt.want) {
+			t.Errorf("FindBestMatchJDKContext(%q) did not explain its choice: %q", tt.selector, logs.String())
+		}
+	}
+}
+
+func TestFindBestMatchJDKByDistributionVersion(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	jdks := []discovery.JDK{
+		{Identifier: "zulu@21.0.4", Version: "21.0.4", Source: "javm"},
+		{Identifier: "zulu@21.0.2", Version: "21.0.2", Source: "javm"},
+		{Identifier: "system@21.0.3", Version: "21.0.3", Source: "system", DistributionVersion: "21.34.19"},
+	}
+	if err := saveReceipt("zulu@21.0.4", installReceipt{Distribution: "zulu", DistributionVersion: "21.36.17"}); err != nil {
+		t.Fatal(err)
+	}
+	// The release file is used when there is no receipt.
+	jdks[1].DistributionVersion = "21.32.17"
+
+	tests := []struct {
+		selector string
+		want     string
+		wantErr  bool
+	}{
+		{"zulu@dist:21.36.17", "zulu@21.0.4", false},
+		{"zulu@dist:21.32", "zulu@21.0.2", false},
+		{"dist:21.34.19", "system@21.0.3", false},
+		{"zulu@dist:21.34.19", "", true},
+		{"dist:22", "", true},
+	}
+	for _, tt := range tests {
+		got, err := FindBestMatchJDKContext(context.Background(), jdks, tt.selector)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("FindBestMatchJDKContext(%q) error = %v, wantErr %v", tt.selector, err, tt.wantErr)
+			continue
+		}
+		if got.Identifier != tt.want {
+			t.Errorf("FindBestMatchJDKContext(%q) = %v, want %v", tt.selector, got.Identifier, tt.want)
+		}
+	}
+}
+
 func TestNewLsCommand_Output(t *testing.T) {
 	cleanup := setupMockLs()
 	defer cleanup()
@@ -0,0 +1,90 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/internal/state"
+	"github.com/felipebz/javm/semver"
+	"github.com/spf13/cobra"
+)
+
+func NewPinCommand(client CatalogClient) *cobra.Command {
+	var fromBuild bool
+	cmd := &cobra.Command{
+		Use:   "pin [version]",
+		Short: "Pin the Java version of the current directory in .java-version",
+		Args:  UsageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var selector string
+			switch {
+			case fromBuild && len(args) != 0:
+				return UsageError(errors.New("--from-build cannot be combined with a version argument"))
+			case fromBuild:
+				var err error
+				if selector, err = buildJavaVersion(cmd.Context()); err != nil {
+					return err
+				}
+			case len(args) == 0:
+				return UsageError(errors.New("a version or --from-build is required"))
+			default:
+				selector = args[0]
+			}
+			if _, err := pin(cmd.Context(), client, selector, false); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Pinned Java version %s in %s\n", selector, cfg.JavaVersionFile); err != nil {
+				return fmt.Errorf("write pin confirmation: %w", err)
+			}
+			return nil
+		},
+		Example: "  javm pin temurin@21\n" +
+			"  javm pin --from-build # use the version declared by Gradle or Maven",
+	}
+	cmd.Flags().BoolVar(&fromBuild, "from-build", false, "infer the version from Gradle or Maven build files")
+	return cmd
+}
+
+// buildJavaVersion returns the Java version the build of the current
+// directory declares.
+func buildJavaVersion(ctx context.Context) (string, error) {
+	selector, path := cfg.LookupBuildJavaVersion()
+	if selector == "" {
+		return "", NotFoundError(errors.New("no Java version found in Gradle or Maven build files"))
+	}
+	loggerFromContext(ctx).Info("Found ", selector, " in ", path)
+	return selector, nil
+}
+
+// pin writes selector to the .java-version file of the current directory,
+// once it selects an installed JDK or one that can be installed. With exact,
+// the version it resolves to is written instead. It returns what was written.
+func pin(ctx context.Context, client CatalogClient, selector string, exact bool) (string, error) {
+	selector = strings.TrimSpace(selector)
+	if strings.ContainsAny(selector, "\r\n\x00") || selector == "" {
+		return "", UsageError(fmt.Errorf("invalid version %q", selector))
+	}
+	if _, err := semver.ParseRange(selector); err != nil {
+		return "", UsageError(err)
+	}
+	ver, err := resolveLocalSelector(ctx, client, selector)
+	if err != nil {
+		return "", err
+	}
+	if exact {
+		selector = ver.String()
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	if err := state.AtomicWriteFile(filepath.Join(cwd, cfg.JavaVersionFile), []byte(selector+"\n"), 0o644); err != nil {
+		return "", fmt.Errorf("write %s: %w", cfg.JavaVersionFile, err)
+	}
+	return selector, nil
+}
@@ -0,0 +1,68 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/felipebz/javm/discoapi"
+	"github.com/felipebz/javm/discovery"
+)
+
+func TestPinFromBuild(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	t.Setenv("JAVM_CEILING_DIRECTORIES", "")
+	project := t.TempDir()
+	if err := os.WriteFile(filepath.Join(project, "build.gradle.kts"),
+		[]byte("java { toolchain { languageVersion = JavaLanguageVersion.of(21) } }\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(project)
+	cleanup := setupMockLs()
+	defer cleanup()
+	mockLsResult = []discovery.JDK{{Identifier: "temurin@21.0.2", Version: "21.0.2", Source: "javm", Path: "/tmp/jdk/temurin@21.0.2"}}
+
+	cmd := NewPinCommand(nil)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--from-build"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(project, ".java-version"))
+	if err != nil || string(data) != "21\n" {
+		t.Fatalf(".java-version = %q, %v", data, err)
+	}
+}
+
+func TestPinRejectsInvalidInput(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	t.Setenv("JAVM_CEILING_DIRECTORIES", "")
+	t.Chdir(t.TempDir())
+	cleanup := setupMockLs()
+	defer cleanup()
+	mockLsResult = []discovery.JDK{}
+	client := &queryRecordingClient{packages: func(discoapi.PackageQuery) []discoapi.Package { return nil }}
+	tests := []struct {
+		args []string
+		want error
+	}{
+		{nil, ErrUsage},
+		{[]string{"not a version"}, ErrUsage},
+		{[]string{"--from-build", "21"}, ErrUsage},
+		{[]string{"--from-build"}, ErrNotFound},
+		{[]string{"temurin@21"}, ErrNotFound},
+	}
+	for _, tt := range tests {
+		cmd := NewPinCommand(client)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs(tt.args)
+		if err := cmd.Execute(); !errors.Is(err, tt.want) {
+			t.Errorf("%v: got %v, want %v", tt.args, err, tt.want)
+		}
+	}
+	if _, err := os.Stat(".java-version"); !os.IsNotExist(err) {
+		t.Fatalf(".java-version was written: %v", err)
+	}
+}
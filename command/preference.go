@@ -0,0 +1,62 @@
+package command
+
+import (
+	"context"
+
+	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/discovery"
+	"github.com/felipebz/javm/semver"
+)
+
+// jdkPreference orders installed JDKs by the java.preferred_distributions and
+// java.preferred_sources settings, so that "21" picks the same vendor
+// whichever command resolves it.
+type jdkPreference struct {
+	distributions []string
+	sources       []string
+}
+
+func loadJDKPreference(ctx context.Context) jdkPreference {
+	var p jdkPreference
+	if value, err := cfg.EffectiveValue("java.preferred_distributions"); err == nil {
+		distributions := knownDistributions(ctx, nil)
+		for _, name := range cfg.SplitList(value) {
+			if canonical, ok := canonicalDistribution(name, distributions); ok && !semver.IsQualifierPattern(name) {
+				name = canonical
+			}
+			p.distributions = append(p.distributions, name)
+		}
+	}
+	if value, err := cfg.EffectiveValue("java.preferred_sources"); err == nil {
+		p.sources = cfg.SplitList(value)
+	}
+	return p
+}
+
+// compare orders a before b when a's distribution, and then its source, is
+// listed earlier.
+func (p jdkPreference) compare(a, b discovery.JDK) int {
+	if c := preferenceRank(p.distributions, jdkQualifier(a)) - preferenceRank(p.distributions, jdkQualifier(b)); c != 0 {
+		return c
+	}
+	return preferenceRank(p.sources, a.Source) - preferenceRank(p.sources, b.Source)
+}
+
+// preferenceRank returns the position of the first pattern matching value,
+// or len(patterns) when none does.
+func preferenceRank(patterns []string, value string) int {
+	for i, pattern := range patterns {
+		if semver.MatchesQualifierPattern(pattern, value) {
+			return i
+		}
+	}
+	return len(patterns)
+}
+
+// jdkQualifier returns the distribution part of jdk's identifier.
+func jdkQualifier(jdk discovery.JDK) string {
+	if v, err := semver.ParseVersion(jdk.Identifier); err == nil {
+		return v.Qualifier()
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/discovery"
+)
+
+func TestFindBestMatchJDKAppliesConfiguredPreferences(t *testing.T) {
+	t.Setenv("JAVM_HOME", t.TempDir())
+	jdks := []discovery.JDK{
+		{Identifier: "oracle-corporation-intellij@21", Version: "21.0.6", Source: "intellij"},
+		{Identifier: "zulu@21.0.4", Version: "21.0.4", Source: "javm"},
+		{Identifier: "temurin@21.0.2", Version: "21.0.2", Source: "javm"},
+		{Identifier: "temurin@21.0.1", Version: "21.0.1", Source: "javm"},
+		{Identifier: "eclipse-adoptium-system@21", Version: "21.0.3", Source: "system"},
+	}
+	tests := []struct {
+		distributions string
+		sources       string
+		selector      string
+		want          string
+	}{
+		{"*", "javm,*", "21", "zulu@21.0.4"},
+		{"temurin,zulu,*", "javm,*", "21", "temurin@21.0.2"},
+		{"oracle*,*", "javm,*", "21", "oracle-corporation-intellij@21"},
+		{"*", "system,javm,*", "21", "eclipse-adoptium-system@21"},
+		{"*", "*", "21", "oracle-corporation-intellij@21"},
+		// The selector's own distributions come first.
+		{"temurin,*", "javm,*", "zulu|temurin@21", "zulu@21.0.4"},
+	}
+	for _, tt := range tests {
+		if err := cfg.SetValue("java.preferred_distributions", tt.distributions); err != nil {
+			t.Fatal(err)
+		}
+		if err := cfg.SetValue("java.preferred_sources", tt.sources); err != nil {
+			t.Fatal(err)
+		}
+		got, err := FindBestMatchJDK(jdks, tt.selector)
+		if err != nil || got.Identifier != tt.want {
+			t.Errorf("distributions %q, sources %q: FindBestMatchJDK(%s) = %q, %v; want %q",
+				tt.distributions, tt.sources, tt.selector, got.Identifier, err, tt.want)
+		}
+	}
+
+	if err := cfg.SetValue("java.preferred_sources", "javm,sdkman"); err == nil {
+		t.Errorf("expected unknown sources to be rejected")
+	}
+}
@@ -0,0 +1,57 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/discovery"
+)
+
+// installReceipt records the DiscoAPI package a managed JDK was installed
+// from, so that it can be selected by details the JDK itself doesn't carry.
+type installReceipt struct {
+	Distribution        string    `json:"distribution"`
+	JavaVersion         string    `json:"java_version"`
+	DistributionVersion string    `json:"distribution_version"`
+	PackageID           string    `json:"package_id"`
+	ArchiveType         string    `json:"archive_type,omitempty"`
+	URL                 string    `json:"url"`
+	Checksum            string    `json:"checksum,omitempty"`
+	ChecksumType        string    `json:"checksum_type,omitempty"`
+	Installed           time.Time `json:"installed"`
+}
+
+func receiptFile(identifier string) string {
+	return filepath.Join(cfg.Dir(), "receipts", identifier+".json")
+}
+
+func saveReceipt(identifier string, receipt installReceipt) error {
+	return saveJSONCache(receiptFile(identifier), receipt)
+}
+
+func loadReceipt(identifier string) (installReceipt, bool, error) {
+	var receipt installReceipt
+	ok, err := loadJSONCache(receiptFile(identifier), &receipt)
+	return receipt, ok, err
+}
+
+func removeReceipt(identifier string) error {
+	if err := os.Remove(receiptFile(identifier)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove install receipt: %w", err)
+	}
+	return nil
+}
+
+// localDistributionVersion returns the vendor version of an installed JDK,
+// preferring the install receipt over the one read from its release file.
+func localDistributionVersion(jdk discovery.JDK) string {
+	if jdk.Source == "javm" {
+		if receipt, ok, err := loadReceipt(jdk.Identifier); err == nil && ok && receipt.DistributionVersion != "" {
+			return receipt.DistributionVersion
+		}
+	}
+	return jdk.DistributionVersion
+}
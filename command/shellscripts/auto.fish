@@ -0,0 +1,6 @@
+
+function __javm_auto --on-variable PWD
+    status --is-command-substitution; and return
+    javm use --auto
+end
+__javm_auto
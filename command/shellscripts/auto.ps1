@@ -0,0 +1,13 @@
+
+$global:JavmAutoPwd = $null
+$global:JavmOriginalPrompt = $function:prompt
+function global:prompt
+{
+    $code = $global:LASTEXITCODE
+    if ($global:JavmAutoPwd -ne $PWD.Path) {
+        $global:JavmAutoPwd = $PWD.Path
+        javm use --auto
+    }
+    $global:LASTEXITCODE = $code
+    & $global:JavmOriginalPrompt
+}
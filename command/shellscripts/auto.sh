@@ -0,0 +1,20 @@
+
+_javm_auto() {
+    local rc=$?
+    if [ "$PWD" != "${_JAVM_AUTO_PWD:-}" ]; then
+        _JAVM_AUTO_PWD=$PWD
+        javm use --auto
+    fi
+    return $rc
+}
+
+if [ -n "${ZSH_VERSION:-}" ]; then
+    autoload -Uz add-zsh-hook
+    add-zsh-hook chpwd _javm_auto
+else
+    case ";${PROMPT_COMMAND:-};" in
+        *";_javm_auto;"*) ;;
+        *) PROMPT_COMMAND="_javm_auto${PROMPT_COMMAND:+;$PROMPT_COMMAND}" ;;
+    esac
+fi
+_javm_auto
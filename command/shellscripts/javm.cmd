@@ -26,6 +26,7 @@ set "_JAVM_TEMP_DIR=%TEMP%\javm-%RANDOM%-%RANDOM%-%RANDOM%"
 2>nul mkdir "%_JAVM_TEMP_DIR%"
 if errorlevel 1 goto javm_environment_command
 set "_JAVM_ENV_FILE=%_JAVM_TEMP_DIR%\environment"
+set "_JAVM_EXTRA_FILE=%_JAVM_TEMP_DIR%\extra.cmd"
 
 if defined _JAVM_APPLY_DEFAULT goto javm_run_default
 "%_JAVM_EXECUTABLE%" --fd3 "%_JAVM_ENV_FILE%" %*
@@ -42,11 +43,20 @@ if not "%_JAVM_EXIT_CODE%"=="0" goto javm_cleanup
 if not exist "%_JAVM_ENV_FILE%" if defined _JAVM_APPLY_DEFAULT goto javm_skip_default
 if not exist "%_JAVM_ENV_FILE%" goto javm_missing_environment
 
+rem Variables other than PATH and JAVA_HOME, such as JAVA_HOME_21_X64, are
+rem written to extra.cmd, which runs after endlocal. Values are passed to the
+rem helpers in variables, as arguments of call would be expanded again.
+set "_JAVM_NEW_PATH=%PATH%"
 for /f "usebackq tokens=1,2,* delims=	" %%A in ("%_JAVM_ENV_FILE%") do (
     if /i "%%A"=="SET" if /i "%%B"=="PATH" set "_JAVM_NEW_PATH=%%C"
+    if "%%A"=="PATH_PREPEND" set "_JAVM_PATH_ARG=%%B" & call :javm_path_prepend
+    if "%%A"=="PATH_REMOVE" set "_JAVM_PATH_ARG=%%B" & call :javm_path_remove
+    if "%%A"=="PATH_REMOVE_PREFIX" set "_JAVM_PATH_ARG=%%B" & call :javm_path_remove_prefix
     if /i "%%A"=="SET" if /i "%%B"=="JAVA_HOME" set "_JAVM_NEW_JAVA_HOME=%%C"
     if /i "%%A"=="SET" if /i "%%B"=="JAVA_HOME_BEFORE_JAVM" set "_JAVM_NEW_JAVA_HOME_BEFORE_JAVM=%%C"
     if /i "%%A"=="UNSET" if /i "%%B"=="JAVA_HOME_BEFORE_JAVM" set "_JAVM_UNSET_JAVA_HOME_BEFORE_JAVM=1"
+    if /i "%%A"=="SET" if /i not "%%B"=="PATH" if /i not "%%B"=="JAVA_HOME" if /i not "%%B"=="JAVA_HOME_BEFORE_JAVM" set "_JAVM_EXTRA_VALUE=%%C" & call :javm_extra_set "%%B"
+    if /i "%%A"=="UNSET" if /i not "%%B"=="JAVA_HOME_BEFORE_JAVM" >>"%_JAVM_EXTRA_FILE%" echo set "%%B="
 )
 
 if defined _JAVM_APPLY_DEFAULT goto javm_apply_default
@@ -58,20 +68,19 @@ goto javm_apply_deactivate
 set "_JAVM_EXIT_CODE=1"
 
 :javm_cleanup
-del /q "%_JAVM_ENV_FILE%" >nul 2>&1
+del /q "%_JAVM_ENV_FILE%" "%_JAVM_EXTRA_FILE%" >nul 2>&1
 rmdir "%_JAVM_TEMP_DIR%" >nul 2>&1
 endlocal & exit /b %_JAVM_EXIT_CODE%
 
 :javm_skip_default
-del /q "%_JAVM_ENV_FILE%" >nul 2>&1
+del /q "%_JAVM_ENV_FILE%" "%_JAVM_EXTRA_FILE%" >nul 2>&1
 rmdir "%_JAVM_TEMP_DIR%" >nul 2>&1
 endlocal & set "_JAVM_DEFAULT_INITIALIZED=1"
 goto javm_after_default
 
 :javm_apply_default
 del /q "%_JAVM_ENV_FILE%" >nul 2>&1
-rmdir "%_JAVM_TEMP_DIR%" >nul 2>&1
-endlocal & set "PATH=%_JAVM_NEW_PATH%" & set "JAVA_HOME=%_JAVM_NEW_JAVA_HOME%" & set "JAVA_HOME_BEFORE_JAVM=%_JAVM_NEW_JAVA_HOME_BEFORE_JAVM%" & set "_JAVM_DEFAULT_INITIALIZED=1"
+endlocal & set "PATH=%_JAVM_NEW_PATH%" & set "JAVA_HOME=%_JAVM_NEW_JAVA_HOME%" & set "JAVA_HOME_BEFORE_JAVM=%_JAVM_NEW_JAVA_HOME_BEFORE_JAVM%" & set "_JAVM_DEFAULT_INITIALIZED=1" & call :javm_apply_extra "%_JAVM_TEMP_DIR%"
 
 :javm_after_default
 setlocal DisableDelayedExpansion
@@ -80,10 +89,68 @@ goto javm_dispatch
 
 :javm_apply_use
 del /q "%_JAVM_ENV_FILE%" >nul 2>&1
-rmdir "%_JAVM_TEMP_DIR%" >nul 2>&1
-endlocal & set "PATH=%_JAVM_NEW_PATH%" & set "JAVA_HOME=%_JAVM_NEW_JAVA_HOME%" & set "JAVA_HOME_BEFORE_JAVM=%_JAVM_NEW_JAVA_HOME_BEFORE_JAVM%" & set "_JAVM_DEFAULT_INITIALIZED=1" & exit /b %_JAVM_EXIT_CODE%
+endlocal & set "PATH=%_JAVM_NEW_PATH%" & set "JAVA_HOME=%_JAVM_NEW_JAVA_HOME%" & set "JAVA_HOME_BEFORE_JAVM=%_JAVM_NEW_JAVA_HOME_BEFORE_JAVM%" & set "_JAVM_DEFAULT_INITIALIZED=1" & call :javm_apply_extra "%_JAVM_TEMP_DIR%" & exit /b %_JAVM_EXIT_CODE%
 
 :javm_apply_deactivate
 del /q "%_JAVM_ENV_FILE%" >nul 2>&1
-rmdir "%_JAVM_TEMP_DIR%" >nul 2>&1
-endlocal & set "PATH=%_JAVM_NEW_PATH%" & set "JAVA_HOME=%_JAVM_NEW_JAVA_HOME%" & set "JAVA_HOME_BEFORE_JAVM=" & set "_JAVM_DEFAULT_INITIALIZED=1" & exit /b %_JAVM_EXIT_CODE%
+endlocal & set "PATH=%_JAVM_NEW_PATH%" & set "JAVA_HOME=%_JAVM_NEW_JAVA_HOME%" & set "JAVA_HOME_BEFORE_JAVM=" & set "_JAVM_DEFAULT_INITIALIZED=1" & call :javm_apply_extra "%_JAVM_TEMP_DIR%" & exit /b %_JAVM_EXIT_CODE%
+
+rem Runs extra.cmd in the caller's environment, then removes the temporary directory.
+:javm_apply_extra
+if exist "%~1\extra.cmd" call "%~1\extra.cmd"
+del /q "%~1\extra.cmd" >nul 2>&1
+rmdir "%~1" >nul 2>&1
+exit /b 0
+
+rem Appends "set NAME=_JAVM_EXTRA_VALUE" to extra.cmd, doubling %% so that
+rem calling extra.cmd restores the value.
+:javm_extra_set
+setlocal EnableDelayedExpansion
+>>"%_JAVM_EXTRA_FILE%" echo set "%~1=!_JAVM_EXTRA_VALUE:%%=%%%%!"
+endlocal
+exit /b 0
+
+rem PATH operations on _JAVM_NEW_PATH, taking their directory from _JAVM_PATH_ARG.
+
+:javm_path_prepend
+call :javm_path_remove
+if not defined _JAVM_NEW_PATH set "_JAVM_NEW_PATH=%_JAVM_PATH_ARG%" & exit /b 0
+set "_JAVM_NEW_PATH=%_JAVM_PATH_ARG%;%_JAVM_NEW_PATH%"
+exit /b 0
+
+:javm_path_remove
+set "_JAVM_PATH_MATCH=%_JAVM_PATH_ARG%"
+set "_JAVM_PATH_PREFIX="
+goto javm_path_filter
+
+:javm_path_remove_prefix
+set "_JAVM_PATH_MATCH="
+set "_JAVM_PATH_PREFIX=%_JAVM_PATH_ARG%"
+goto javm_path_filter
+
+:javm_path_filter
+set "_JAVM_PATH_REST=%_JAVM_NEW_PATH%"
+set "_JAVM_NEW_PATH="
+
+:javm_path_filter_next
+if not defined _JAVM_PATH_REST exit /b 0
+set "_JAVM_PATH_LIST=%_JAVM_PATH_REST%"
+set "_JAVM_PATH_REST="
+set "_JAVM_PATH_ENTRY="
+for /f "tokens=1,* delims=;" %%P in ("%_JAVM_PATH_LIST%") do set "_JAVM_PATH_ENTRY=%%P" & set "_JAVM_PATH_REST=%%Q"
+if not defined _JAVM_PATH_ENTRY goto javm_path_filter_next
+if defined _JAVM_PATH_MATCH if /i "%_JAVM_PATH_ENTRY%"=="%_JAVM_PATH_MATCH%" goto javm_path_filter_next
+if not defined _JAVM_PATH_PREFIX goto javm_path_filter_keep
+rem Removing "#<prefix>" only changes the entry when it starts with the prefix.
+rem Delayed expansion reads the values without expanding them a second time.
+setlocal EnableDelayedExpansion
+set "_JAVM_PATH_TEST=#!_JAVM_PATH_ENTRY!"
+for /f "delims=" %%X in ("!_JAVM_PATH_PREFIX!") do set "_JAVM_PATH_TEST=!_JAVM_PATH_TEST:#%%X=!"
+if "!_JAVM_PATH_TEST!"=="#!_JAVM_PATH_ENTRY!" endlocal & goto javm_path_filter_keep
+endlocal
+goto javm_path_filter_next
+
+:javm_path_filter_keep
+if not defined _JAVM_NEW_PATH set "_JAVM_NEW_PATH=%_JAVM_PATH_ENTRY%" & goto javm_path_filter_next
+set "_JAVM_NEW_PATH=%_JAVM_NEW_PATH%;%_JAVM_PATH_ENTRY%"
+goto javm_path_filter_next
@@ -1,3 +1,20 @@
+function __javm_path_filter
+    # Drops the PATH entries equal to $argv[2] (mode "exact") or starting with
+    # it (mode "prefix").
+    set -l mode $argv[1]
+    set -l value $argv[2]
+    set -l kept
+    for entry in $PATH
+        set -l head "$entry"
+        if test "$mode" = prefix
+            set head (string sub -l (string length -- "$value") -- "$entry")
+        end
+        test "$head" = "$value"; and continue
+        set -a kept $entry
+    end
+    set -gx PATH $kept
+end
+
 function javm
     set -l javm_executable "::JAVM::"
 
@@ -20,6 +37,13 @@ function javm
                         if test (count $parts) -ge 2
                             set -e $parts[2]
                         end
+                    else if test "$op" = PATH_PREPEND
+                        __javm_path_filter exact $parts[2]
+                        set -gx PATH $parts[2] $PATH
+                    else if test "$op" = PATH_REMOVE
+                        __javm_path_filter exact $parts[2]
+                    else if test "$op" = PATH_REMOVE_PREFIX
+                        __javm_path_filter prefix $parts[2]
                     end
                 end < $fd3
             end
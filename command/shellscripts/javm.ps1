@@ -1,3 +1,15 @@
+function global:JavmPathFilter([string]$Mode, [string]$Value)
+{
+    # Drops the PATH entries equal to $Value (mode "exact") or starting with it
+    # (mode "prefix"), ignoring case like Windows does.
+    $separator = [System.IO.Path]::PathSeparator
+    $entries = @($env:PATH -split [regex]::Escape($separator) | Where-Object {
+        if ($Mode -eq 'exact') { $_ -ne $Value }
+        else { -not $_.StartsWith($Value, [System.StringComparison]::OrdinalIgnoreCase) }
+    })
+    $env:PATH = $entries -join $separator
+}
+
 function global:javm
 {
     $javmExecutable = '::JAVM::'
@@ -10,6 +22,12 @@ function global:javm
             $parts = $_ -split "`t",3
             if ($parts.Length -eq 3 -and $parts[0] -eq 'SET') { Set-Item -Path env:$($parts[1]) -Value $parts[2] }
             elseif ($parts.Length -ge 2 -and $parts[0] -eq 'UNSET') { Remove-Item -ErrorAction SilentlyContinue -Path env:$($parts[1]) }
+            elseif ($parts.Length -ge 2 -and $parts[0] -eq 'PATH_PREPEND') {
+                JavmPathFilter 'exact' $parts[1]
+                $env:PATH = $parts[1] + [System.IO.Path]::PathSeparator + $env:PATH
+            }
+            elseif ($parts.Length -ge 2 -and $parts[0] -eq 'PATH_REMOVE') { JavmPathFilter 'exact' $parts[1] }
+            elseif ($parts.Length -ge 2 -and $parts[0] -eq 'PATH_REMOVE_PREFIX') { JavmPathFilter 'prefix' $parts[1] }
         }
         Remove-Item -Force $fd3
         $global:LASTEXITCODE = $code
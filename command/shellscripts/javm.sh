@@ -1,3 +1,20 @@
+_javm_path_filter() {
+    # Drops the PATH entries equal to $2 (mode "exact") or starting with it
+    # (mode "prefix"). Other entries, empty ones included, keep their place.
+    local entry result= sep= rest="$PATH:"
+    while [ -n "$rest" ]; do
+        entry=${rest%%:*}
+        rest=${rest#*:}
+        case $1 in
+            exact) [ "$entry" = "$2" ] && continue ;;
+            prefix) case $entry in "$2"*) continue ;; esac ;;
+        esac
+        result="$result$sep$entry"
+        sep=:
+    done
+    export PATH="$result"
+}
+
 javm() {
     local javm_executable="::JAVM::"
 
@@ -10,8 +27,16 @@ javm() {
 
         if [ -s "$fd3" ]; then
             while IFS=$'\t' read -r op key val; do
-                [ "$op" = "SET" ] && export "$key=$val"
-                [ "$op" = "UNSET" ] && unset "$key"
+                case $op in
+                    SET) export "$key=$val" ;;
+                    UNSET) unset "$key" ;;
+                    PATH_PREPEND)
+                        _javm_path_filter exact "$key"
+                        export PATH="$key${PATH:+:$PATH}"
+                        ;;
+                    PATH_REMOVE) _javm_path_filter exact "$key" ;;
+                    PATH_REMOVE_PREFIX) _javm_path_filter prefix "$key" ;;
+                esac
             done < "$fd3"
         fi
 
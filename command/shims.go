@@ -0,0 +1,197 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/felipebz/javm/cfg"
+	"github.com/spf13/cobra"
+)
+
+func NewShimsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shims",
+		Short: "Manage launchers for JDK tools that pick the JDK when run",
+		Long: "Shims are launchers for java, javac and the other tools of managed JDKs.\n" +
+			"Put the shims directory on PATH for programs that do not run the shell\n" +
+			"integration, such as IDEs and services. Each shim runs the tool of the JDK\n" +
+			"selected by JAVM_VERSION, the nearest .java-version or the default version.",
+		Args: UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "rehash",
+		Short: "Regenerate shims for the tools of managed JDKs",
+		Args:  UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tools, err := rehashShims()
+			if err != nil {
+				return err
+			}
+			loggerFromContext(cmd.Context()).Info("Created ", len(tools), " shims in ", shimsDir())
+			return nil
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "path",
+		Short: "Print the shims directory",
+		Args:  UsageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := fmt.Fprintln(cmd.OutOrStdout(), shimsDir()); err != nil {
+				return fmt.Errorf("write shims directory: %w", err)
+			}
+			return nil
+		},
+	})
+	return cmd
+}
+
+func shimsDir() string {
+	return filepath.Join(cfg.Dir(), "shims")
+}
+
+// rehashShims replaces the shims with one for every executable in the bin
+// directory of a managed JDK, and returns their names.
+func rehashShims() ([]string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("locate javm executable: %w", err)
+	}
+	tools, err := managedJDKTools()
+	if err != nil {
+		return nil, err
+	}
+	dir := shimsDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create shims directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read shims directory: %w", err)
+	}
+	for _, entry := range entries {
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return nil, fmt.Errorf("remove shim %s: %w", entry.Name(), err)
+		}
+	}
+	for _, tool := range tools {
+		if err := createShim(executable, filepath.Join(dir, tool)); err != nil {
+			return nil, fmt.Errorf("create shim %s: %w", tool, err)
+		}
+	}
+	return tools, nil
+}
+
+// refreshShims rehashes the shims after JDKs were installed or removed, if
+// the user created them.
+func refreshShims(ctx context.Context) {
+	if _, err := os.Stat(shimsDir()); err != nil {
+		return
+	}
+	if _, err := rehashShims(); err != nil {
+		loggerFromContext(ctx).Warn("Failed to update shims: ", err)
+	}
+}
+
+// managedJDKTools returns the sorted names of the executables of managed JDKs.
+func managedJDKTools() ([]string, error) {
+	jdks, err := os.ReadDir(filepath.Join(cfg.Dir(), "jdk"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read managed JDK directory: %w", err)
+	}
+	var tools []string
+	for _, jdk := range jdks {
+		home := filepath.Join(cfg.Dir(), "jdk", jdk.Name())
+		if runtime.GOOS == "darwin" {
+			home = filepath.Join(home, "Contents", "Home")
+		}
+		entries, err := os.ReadDir(filepath.Join(home, "bin"))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil || !info.Mode().IsRegular() || !isExecutable(entry.Name(), info) {
+				continue
+			}
+			if !slices.Contains(tools, entry.Name()) {
+				tools = append(tools, entry.Name())
+			}
+		}
+	}
+	slices.Sort(tools)
+	return tools, nil
+}
+
+func isExecutable(name string, info fs.FileInfo) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(name), ".exe")
+	}
+	return info.Mode()&0o111 != 0
+}
+
+// ShimTool reports whether javm was started through a shim, which is the case
+// when it runs under the name of a file in the shims directory, and returns
+// the name of the tool.
+func ShimTool(arg0 string) (string, bool) {
+	name := filepath.Base(arg0)
+	tool := strings.TrimSuffix(name, filepath.Ext(name))
+	if tool == "javm" || tool == "" {
+		return "", false
+	}
+	if runtime.GOOS == "windows" && filepath.Ext(name) == "" {
+		name += ".exe"
+	}
+	if info, err := os.Lstat(filepath.Join(shimsDir(), name)); err != nil || info.IsDir() {
+		return "", false
+	}
+	return name, true
+}
+
+// RunShim runs tool from the JDK selected by JAVM_VERSION, the nearest
+// .java-version or the default version, with JAVA_HOME and PATH set for it.
+func RunShim(ctx context.Context, tool string, args []string) error {
+	selector := os.Getenv("JAVM_VERSION")
+	if selector == "" {
+		selector, _ = cfg.LookupJavaVersion()
+	}
+	if selector == "" {
+		var err error
+		if selector, err = readDefaultVersion(); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return UsageError(fmt.Errorf("no JDK selected for %s: set JAVM_VERSION, add a .java-version or set a default version", tool))
+			}
+			return err
+		}
+	}
+	// Unlike `use`, shims skip lifecycle warnings, which would end up in
+	// the output of tools such as `java -version`.
+	jdk, jdks, err := resolveJDK(ctx, selector)
+	if err != nil {
+		return err
+	}
+	out, err := usePath(jdk.Path, unmanagedJDKBinDirs(jdks)...)
+	if err != nil {
+		return err
+	}
+	if err := applyEnvironment(out); err != nil {
+		return err
+	}
+	path := filepath.Join(os.Getenv("JAVA_HOME"), "bin", tool)
+	if _, err := os.Stat(path); err != nil {
+		return NotFoundError(fmt.Errorf("%s has no %s", jdk.Identifier, tool))
+	}
+	return runChild(path, append([]string{path}, args...), os.Environ())
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package command
+
+import "os"
+
+// createShim links shim to the javm executable, which acts as the shim when
+// started under another name.
+func createShim(executable, shim string) error {
+	return os.Symlink(executable, shim)
+}
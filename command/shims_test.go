@@ -0,0 +1,130 @@
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/felipebz/javm/discovery"
+)
+
+// writeJDKTool creates an executable named tool in the bin directory of the
+// JDK at path and returns its JAVA_HOME.
+func writeJDKTool(t *testing.T, path, tool string) string {
+	t.Helper()
+	home := path
+	if runtime.GOOS == "darwin" {
+		home = filepath.Join(path, "Contents", "Home")
+	}
+	if runtime.GOOS == "windows" {
+		tool += ".exe"
+	}
+	if err := os.MkdirAll(filepath.Join(home, "bin"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, "bin", tool), nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return home
+}
+
+func exeName(tool string) string {
+	if runtime.GOOS == "windows" {
+		return tool + ".exe"
+	}
+	return tool
+}
+
+func TestRehashShims(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("JAVM_HOME", home)
+	writeJDKTool(t, filepath.Join(home, "jdk", "temurin@21.0.1"), "java")
+	writeJDKTool(t, filepath.Join(home, "jdk", "temurin@21.0.1"), "javac")
+	jdk17 := writeJDKTool(t, filepath.Join(home, "jdk", "zulu@17.0.9"), "java")
+	writeJDKTool(t, filepath.Join(home, "jdk", "zulu@17.0.9"), "jshell")
+	if runtime.GOOS != "windows" {
+		if err := os.WriteFile(filepath.Join(jdk17, "bin", "README"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(shimsDir(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(shimsDir(), exeName("stale")), nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tools, err := rehashShims()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{exeName("java"), exeName("javac"), exeName("jshell")}
+	if !slices.Equal(tools, want) {
+		t.Fatalf("rehashShims() = %v, want %v", tools, want)
+	}
+	entries, err := os.ReadDir(shimsDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if !slices.Equal(names, want) {
+		t.Fatalf("shims directory holds %v, want %v", names, want)
+	}
+
+	if tool, ok := ShimTool(filepath.Join("/usr", "local", "bin", "java")); !ok || tool != exeName("java") {
+		t.Errorf("ShimTool(java) = %q, %v", tool, ok)
+	}
+	for _, arg0 := range []string{"javm", "stale", "gradle"} {
+		if _, ok := ShimTool(arg0); ok {
+			t.Errorf("ShimTool(%q) reported a shim", arg0)
+		}
+	}
+}
+
+func TestRunShimUsesSelectedJDK(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("JAVM_HOME", home)
+	t.Setenv("PATH", "/usr/bin")
+	t.Setenv("JAVA_HOME", "")
+	t.Setenv("JAVM_VERSION", "zulu@17")
+	t.Setenv("JAVA_HOME_BEFORE_JAVM", "")
+
+	jdkPath := filepath.Join(home, "jdk", "zulu@17.0.9")
+	javaHome := writeJDKTool(t, jdkPath, "java")
+	cleanup := setupMockLs()
+	defer cleanup()
+	mockLsResult = []discovery.JDK{
+		{Identifier: "temurin@21.0.1", Version: "21.0.1", Source: "javm", Path: filepath.Join(home, "jdk", "temurin@21.0.1")},
+		{Identifier: "zulu@17.0.9", Version: "17.0.9", Source: "javm", Path: jdkPath},
+	}
+
+	var gotPath string
+	var gotArgv, gotEnv []string
+	original := runChild
+	defer func() { runChild = original }()
+	runChild = func(path string, argv []string, env []string) error {
+		gotPath, gotArgv, gotEnv = path, argv, env
+		return nil
+	}
+
+	if err := RunShim(context.Background(), exeName("java"), []string{"-version"}); err != nil {
+		t.Fatal(err)
+	}
+	wantPath := filepath.Join(javaHome, "bin", exeName("java"))
+	if gotPath != wantPath || !slices.Equal(gotArgv, []string{wantPath, "-version"}) {
+		t.Fatalf("ran %q %v, want %q", gotPath, gotArgv, wantPath)
+	}
+	if !slices.Contains(gotEnv, "JAVA_HOME="+javaHome) {
+		t.Fatalf("JAVA_HOME was not set for the tool: %v", gotEnv)
+	}
+
+	if err := RunShim(context.Background(), exeName("jshell"), nil); err == nil {
+		t.Fatal("RunShim ran a tool the JDK does not have")
+	}
+}
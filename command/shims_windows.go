@@ -0,0 +1,30 @@
+package command
+
+import (
+	"io"
+	"os"
+)
+
+// createShim hard links shim to the javm executable, which acts as the shim
+// when started under another name. Symbolic links need extra privileges on
+// Windows, and hard links are not possible across volumes, where the
+// executable is copied instead.
+func createShim(executable, shim string) error {
+	if err := os.Link(executable, shim); err == nil {
+		return nil
+	}
+	src, err := os.Open(executable)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(shim, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
@@ -26,6 +26,7 @@ func NewUninstallCommand() *cobra.Command {
 			if err := linkLatest(cmd.Context()); err != nil {
 				return err
 			}
+			refreshShims(cmd.Context())
 			return nil
 		},
 		Example: "  javm uninstall 1.8",
@@ -37,5 +38,8 @@ func uninstall(ctx context.Context, selector string) error {
 	if err != nil {
 		return err
 	}
-	return os.RemoveAll(filepath.Join(cfg.Dir(), "jdk", ver))
+	if err := os.RemoveAll(filepath.Join(cfg.Dir(), "jdk", ver)); err != nil {
+		return err
+	}
+	return removeReceipt(ver)
 }
@@ -6,21 +6,30 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"runtime"
 
 	"github.com/felipebz/javm/cfg"
+	"github.com/felipebz/javm/discovery"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
 func NewUseCommand() *cobra.Command {
 	var useDefault bool
+	var auto bool
+	var also []string
 	cmd := &cobra.Command{
 		Use:   "use [version to use]",
 		Short: "Modify PATH & JAVA_HOME to use specific JDK",
 		Args:  UsageArgs(cobra.MaximumNArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			fd3, _ := cmd.Flags().GetString("fd3")
+			if auto {
+				if len(args) != 0 || useDefault {
+					return UsageError(fmt.Errorf("--auto cannot be combined with a version argument or --default"))
+				}
+				return printForShellToEval(autoUse(cmd.Context()), fd3)
+			}
 			var ver string
 			if useDefault {
 				if len(args) != 0 {
@@ -35,28 +44,31 @@ func NewUseCommand() *cobra.Command {
 					return err
 				}
 			} else if len(args) == 0 {
-				ver = cfg.ReadJavaVersion()
+				ver = projectOrBuildJavaVersion(cmd.Context())
 				if ver == "" {
 					return pflag.ErrHelp
 				}
 			} else {
 				ver = args[0]
 			}
-			fd3, _ := cmd.Flags().GetString("fd3")
 
-			out, err := UseContext(cmd.Context(), ver)
+			out, err := useAlso(cmd.Context(), ver, also)
 			if err != nil {
 				return err
 			}
 			return printForShellToEval(out, fd3)
 		},
 		Example: "  javm use 1.8\n" +
-			"  javm use ~1.8.73 # same as \">=1.8.73 <1.9.0\"",
+			"  javm use ~1.8.73 # same as \">=1.8.73 <1.9.0\"\n" +
+			"  javm use --also 17,11 21 # also set JAVA_HOME_17_X64 and JAVA_HOME_11_X64",
 	}
+	cmd.Flags().StringSliceVar(&also, "also", nil, "also export JAVA_HOME_<major>_<ARCH> for these versions (\"installed\" for every installed major)")
 	cmd.Flags().String("fd3", "", "")
 	_ = cmd.Flags().MarkHidden("fd3")
 	cmd.Flags().BoolVar(&useDefault, "default", false, "use the configured default version")
 	_ = cmd.Flags().MarkHidden("default")
+	cmd.Flags().BoolVar(&auto, "auto", false, "use the version of the current directory, as the hooks of `javm init --auto` do")
+	_ = cmd.Flags().MarkHidden("auto")
 	return cmd
 }
 
@@ -65,6 +77,33 @@ func Use(selector string) ([]string, error) {
 }
 
 func UseContext(ctx context.Context, selector string) ([]string, error) {
+	return useAlso(ctx, selector, nil)
+}
+
+// useAlso is UseContext, also exporting JAVA_HOME_<major>_<ARCH> for the
+// JDKs selected by also.
+func useAlso(ctx context.Context, selector string, also []string) ([]string, error) {
+	jdk, jdks, err := resolveJDK(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLifecycle(ctx, jdk); err != nil {
+		return nil, err
+	}
+	out, err := usePath(jdk.Path, unmanagedJDKBinDirs(jdks)...)
+	if err != nil {
+		return nil, err
+	}
+	homes, err := javaHomeVariables(ctx, jdk, jdks, also)
+	if err != nil {
+		return nil, err
+	}
+	return append(out, homes...), nil
+}
+
+// resolveJDK returns the installed JDK selected by selector, which may be an
+// alias, along with all the JDKs that were discovered.
+func resolveJDK(ctx context.Context, selector string) (discovery.JDK, []discovery.JDK, error) {
 	aliasValue := getAlias(selector)
 	if aliasValue != "" {
 		selector = aliasValue
@@ -72,35 +111,68 @@ func UseContext(ctx context.Context, selector string) ([]string, error) {
 
 	jdks, err := LsContext(ctx, false)
 	if err != nil {
-		return nil, err
+		return discovery.JDK{}, nil, err
 	}
-	jdk, err := FindBestMatchJDK(jdks, selector)
-	if err != nil {
-		return nil, err
+	jdk, err := FindBestMatchJDKContext(ctx, jdks, selector)
+	return jdk, jdks, err
+}
+
+// unmanagedJDKBinDirs returns the bin directories of the JDKs javm discovered
+// outside its own directory, such as Gradle's or those in /usr/lib/jvm.
+func unmanagedJDKBinDirs(jdks []discovery.JDK) []string {
+	var dirs []string
+	for _, jdk := range jdks {
+		if jdk.Source == "javm" {
+			continue
+		}
+		dirs = append(dirs, filepath.Join(jdk.Path, "bin"))
+		if runtime.GOOS == "darwin" {
+			dirs = append(dirs, filepath.Join(jdk.Path, "Contents", "Home", "bin"))
+		}
 	}
-	return usePath(jdk.Path)
+	return dirs
 }
 
-func usePath(path string) ([]string, error) {
-	sep := string(os.PathListSeparator)
-	path, err := filepath.Abs(path)
+// usePath returns the environment changes activating the JDK at path. PATH
+// entries of managed JDKs, and the directories in remove, are dropped; the
+// shell applies these operations to its current PATH.
+func usePath(path string, remove ...string) ([]string, error) {
+	path, err := jdkHome(path)
 	if err != nil {
 		return nil, err
 	}
-	pth, _ := os.LookupEnv("PATH")
-	rgxp := regexp.MustCompile(regexp.QuoteMeta(filepath.Join(cfg.Dir(), "jdk")) + "[^" + sep + "]+[" + sep + "]")
+	bin := filepath.Join(path, "bin")
 	// strip references to managed jdks dir, otherwise leave unchanged
-	pth = rgxp.ReplaceAllString(pth, "")
-	if runtime.GOOS == "darwin" {
-		path = filepath.Join(path, "Contents", "Home")
+	out := []string{"PATH_REMOVE_PREFIX\t" + managedJDKPathPrefix()}
+	for _, dir := range remove {
+		if dir != bin {
+			out = append(out, "PATH_REMOVE\t"+dir)
+		}
 	}
 	systemJavaHome, overrideWasSet := os.LookupEnv("JAVA_HOME_BEFORE_JAVM")
 	if !overrideWasSet {
 		systemJavaHome, _ = os.LookupEnv("JAVA_HOME")
 	}
-	return []string{
-		"SET\tPATH\t" + filepath.Join(path, "bin") + string(os.PathListSeparator) + pth,
-		"SET\tJAVA_HOME\t" + path,
-		"SET\tJAVA_HOME_BEFORE_JAVM\t" + systemJavaHome,
-	}, nil
+	return append(out,
+		"PATH_PREPEND\t"+bin,
+		"SET\tJAVA_HOME\t"+path,
+		"SET\tJAVA_HOME_BEFORE_JAVM\t"+systemJavaHome,
+	), nil
+}
+
+// jdkHome returns the JAVA_HOME of the JDK at path.
+func jdkHome(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if runtime.GOOS == "darwin" {
+		path = filepath.Join(path, "Contents", "Home")
+	}
+	return path, nil
+}
+
+// managedJDKPathPrefix is the prefix of PATH entries in managed JDKs.
+func managedJDKPathPrefix() string {
+	return filepath.Join(cfg.Dir(), "jdk") + string(os.PathSeparator)
 }
@@ -2,10 +2,14 @@ package command
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -53,21 +57,34 @@ func TestUse(t *testing.T) {
 		{Identifier: "1.7.0", Version: "1.7.0", Source: "javm", Path: filepath.Join(cfg.Dir(), "jdk", "1.7.0")},
 		{Identifier: "1.7.2", Version: "1.7.2", Source: "javm", Path: mockJdkPath},
 		{Identifier: "1.8.0", Version: "1.8.0", Source: "javm", Path: filepath.Join(cfg.Dir(), "jdk", "1.8.0")},
+		{Identifier: "gradle@17.0.9", Version: "17.0.9", Source: "gradle", Path: "/gradle/jdks/17"},
 	}
-	os.Setenv("PATH", "/usr/local/bin"+sep+filepath.Join(cfg.Dir(), "jdk", "1.6.0", "bin")+sep+"/usr/bin")
+	os.Setenv("PATH", "/usr/local/bin"+sep+filepath.Join(cfg.Dir(), "jdk", "1.6.0", "bin")+sep+"/gradle/jdks/17/bin"+sep+"/usr/bin")
 	os.Setenv("JAVA_HOME", "/system-jdk")
+	os.Unsetenv("JAVA_HOME_BEFORE_JAVM")
 	actual, err := Use("1.7")
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
 	expected := []string{
-		"SET\tPATH\t" + javaPath + sep + "/usr/local/bin" + sep + "/usr/bin",
-		"SET\tJAVA_HOME\t" + javaHome,
-		"SET\tJAVA_HOME_BEFORE_JAVM\t" + "/system-jdk",
+		"PATH_REMOVE_PREFIX\t" + filepath.Join(cfg.Dir(), "jdk") + string(os.PathSeparator),
+		"PATH_REMOVE\t" + filepath.Join("/gradle/jdks/17", "bin"),
 	}
+	if runtime.GOOS == "darwin" {
+		expected = append(expected, "PATH_REMOVE\t"+filepath.Join("/gradle/jdks/17", "Contents", "Home", "bin"))
+	}
+	expected = append(expected,
+		"PATH_PREPEND\t"+javaPath,
+		"SET\tJAVA_HOME\t"+javaHome,
+		"SET\tJAVA_HOME_BEFORE_JAVM\t"+"/system-jdk",
+	)
 	if !reflect.DeepEqual(actual, expected) {
 		t.Fatalf("actual: %v != expected: %v", actual, expected)
 	}
+	path := resolvePathOps(parseEnvChanges(actual), os.Getenv("PATH"))[0]
+	if want := javaPath + sep + "/usr/local/bin" + sep + "/usr/bin"; path.key != "PATH" || path.value != want {
+		t.Fatalf("PATH after use: %s, want %s", path.value, want)
+	}
 }
 
 func TestUseDefaultReadsSelectorAsData(t *testing.T) {
@@ -115,3 +132,81 @@ func TestUseDefaultRejectsVersionArgument(t *testing.T) {
 		t.Fatal("--default accepted a positional selector")
 	}
 }
+
+func TestUseAutoFollowsJavaVersion(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("JAVM_HOME", home)
+	t.Setenv("PATH", "/usr/bin")
+	t.Setenv("JAVA_HOME", "/system-jdk")
+	for _, key := range []string{"JAVA_HOME_BEFORE_JAVM", autoVersionEnv, autoPreviousEnv} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	cleanup := setupMockLs()
+	defer cleanup()
+	jdkPath := filepath.Join(home, "jdk", "temurin@21.0.1")
+	mockLsResult = []discovery.JDK{{Identifier: "temurin@21.0.1", Version: "21.0.1", Source: "javm", Path: jdkPath}}
+
+	project := t.TempDir()
+	if err := os.WriteFile(filepath.Join(project, ".java-version"), []byte("temurin@21\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(project)
+	out := autoUse(context.Background())
+	if !slices.Contains(out, "SET\t"+autoVersionEnv+"\ttemurin@21") || !slices.Contains(out, "SET\t"+autoPreviousEnv+"\t") {
+		t.Fatalf("entering the project did not record the switch: %v", out)
+	}
+	if !slices.ContainsFunc(out, func(line string) bool { return strings.HasPrefix(line, "SET\tJAVA_HOME\t"+jdkPath) }) {
+		t.Fatalf("entering the project did not switch JDKs: %v", out)
+	}
+
+	// An unchanged selector must not even list the JDKs.
+	t.Setenv(autoVersionEnv, "temurin@21")
+	t.Setenv(autoPreviousEnv, "")
+	t.Setenv("JAVA_HOME_BEFORE_JAVM", "/system-jdk")
+	mockLsError = errors.New("discovery ran")
+	defer func() { mockLsError = nil }()
+	if out := autoUse(context.Background()); len(out) != 0 {
+		t.Fatalf("unchanged selector produced output: %v", out)
+	}
+
+	// Leaving without a default deactivates javm.
+	t.Chdir(t.TempDir())
+	out = autoUse(context.Background())
+	systemJavaHome, err := jdkHome("/system-jdk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"PATH_REMOVE_PREFIX\t" + filepath.Join(home, "jdk") + string(os.PathSeparator),
+		"PATH_PREPEND\t" + filepath.Join(systemJavaHome, "bin"),
+		"SET\tJAVA_HOME\t/system-jdk",
+		"UNSET\tJAVA_HOME_BEFORE_JAVM",
+		"UNSET\t" + autoVersionEnv,
+		"UNSET\t" + autoPreviousEnv,
+	}
+	if !reflect.DeepEqual(out, want) {
+		t.Fatalf("leaving the project: got %v, want %v", out, want)
+	}
+}
+
+func TestUseAutoRestoresPreviousJDK(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("JAVM_HOME", home)
+	t.Setenv("PATH", "/usr/bin")
+	previous := filepath.Join(home, "jdk", "zulu@17.0.9")
+	t.Setenv(autoVersionEnv, "temurin@21")
+	t.Setenv(autoPreviousEnv, previous)
+	t.Setenv("JAVA_HOME_BEFORE_JAVM", "")
+	t.Chdir(t.TempDir())
+
+	out := autoUse(context.Background())
+	javaHome := previous
+	if runtime.GOOS == "darwin" {
+		javaHome = filepath.Join(previous, "Contents", "Home")
+	}
+	if !slices.Contains(out, "SET\tJAVA_HOME\t"+javaHome) || !slices.Contains(out, "UNSET\t"+autoVersionEnv) {
+		t.Fatalf("previous JDK was not restored: %v", out)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"path/filepath"
 	"runtime"
 
-	"github.com/felipebz/javm/cfg"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -20,7 +19,7 @@ func NewWhichCommand() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var ver string
 			if len(args) == 0 {
-				ver = cfg.ReadJavaVersion()
+				ver = projectOrBuildJavaVersion(cmd.Context())
 				if ver == "" {
 					return pflag.ErrHelp
 				}
@@ -56,10 +55,13 @@ func WhichContext(ctx context.Context, selector string, home bool) (string, erro
 	if err != nil {
 		return "", err
 	}
-	jdk, err := FindBestMatchJDK(jdks, selector)
+	jdk, err := FindBestMatchJDKContext(ctx, jdks, selector)
 	if err != nil {
 		return "", err
 	}
+	if err := checkLifecycle(ctx, jdk); err != nil {
+		return "", err
+	}
 	path := jdk.Path
 	if home && runtime.GOOS == "darwin" {
 		path = filepath.Join(path, "Contents", "Home")
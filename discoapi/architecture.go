@@ -0,0 +1,76 @@
+package discoapi
+
+import (
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+)
+
+// architectureNames lists, for each GOARCH value, every architecture name
+// DiscoAPI distributions publish packages under. 32-bit ARM is split by
+// instruction set version because ARMv7 builds do not run on ARMv6 hosts.
+var architectureNames = map[string][]string{
+	"amd64":   {"amd64", "x64"},
+	"386":     {"x86", "i386", "i586", "i686"},
+	"arm64":   {"arm64", "aarch64"},
+	"armv7":   {"arm", "arm32", "armv7", "aarch32"},
+	"armv6":   {"armv6"},
+	"ppc64le": {"ppc64le", "ppc64el"},
+	"ppc64":   {"ppc64"},
+	"s390x":   {"s390x"},
+	"riscv64": {"riscv64"},
+	"mips64":  {"mips64"},
+	"mips":    {"mips"},
+	"loong64": {"loong64", "loongarch64"},
+	"sparc64": {"sparcv9"},
+}
+
+var goarmFn = hostGOARM
+
+// architectureFilter translates a GOARCH value, optionally with an ARM
+// version suffix such as "armv6", into the DiscoAPI architecture parameter.
+// DiscoAPI names such as "aarch64" are accepted as well.
+func architectureFilter(arch string) string {
+	arch = strings.ToLower(strings.TrimSpace(arch))
+	if arch == "" {
+		return ""
+	}
+	if arch == "arm" {
+		switch goarmFn() {
+		case "5", "6":
+			arch = "armv6"
+		default:
+			arch = "armv7"
+		}
+	}
+	if names, ok := architectureNames[arch]; ok {
+		return strings.Join(names, ",")
+	}
+	for _, names := range architectureNames {
+		if slices.Contains(names, arch) {
+			return strings.Join(names, ",")
+		}
+	}
+	return arch
+}
+
+// hostGOARM reports the ARM version javm was built for, which is the best
+// indication of the instruction set the host supports.
+func hostGOARM() string {
+	if runtime.GOARCH != "arm" {
+		return ""
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "GOARM" {
+			// The value may carry a float ABI suffix, e.g. "7,softfloat".
+			version, _, _ := strings.Cut(setting.Value, ",")
+			return version
+		}
+	}
+	return ""
+}
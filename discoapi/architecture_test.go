@@ -0,0 +1,41 @@
+package discoapi
+
+import "testing"
+
+func TestArchitectureFilter(t *testing.T) {
+	tests := []struct {
+		arch  string
+		goarm string
+		want  string
+	}{
+		{arch: "amd64", want: "amd64,x64"},
+		{arch: "arm64", want: "arm64,aarch64"},
+		{arch: "386", want: "x86,i386,i586,i686"},
+		{arch: "arm", goarm: "7", want: "arm,arm32,armv7,aarch32"},
+		{arch: "arm", goarm: "", want: "arm,arm32,armv7,aarch32"},
+		{arch: "arm", goarm: "6", want: "armv6"},
+		{arch: "arm", goarm: "5", want: "armv6"},
+		{arch: "armv6", want: "armv6"},
+		{arch: "ppc64le", want: "ppc64le,ppc64el"},
+		{arch: "ppc64", want: "ppc64"},
+		{arch: "s390x", want: "s390x"},
+		{arch: "riscv64", want: "riscv64"},
+		{arch: "loong64", want: "loong64,loongarch64"},
+		{arch: "aarch64", want: "arm64,aarch64"},
+		{arch: "x64", want: "amd64,x64"},
+		{arch: "mipsle", want: "mipsle"},
+		{arch: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arch+"/"+tt.goarm, func(t *testing.T) {
+			orig := goarmFn
+			goarmFn = func() string { return tt.goarm }
+			defer func() { goarmFn = orig }()
+
+			if got := architectureFilter(tt.arch); got != tt.want {
+				t.Errorf("architectureFilter(%q) = %q, want %q", tt.arch, got, tt.want)
+			}
+		})
+	}
+}
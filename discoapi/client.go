@@ -2,6 +2,7 @@ package discoapi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -57,9 +58,25 @@ func (c *Client) fetch(endpoint string, params url.Values) ([]byte, error) {
 }
 
 func (c *Client) fetchContext(ctx context.Context, endpoint string, params url.Values) (data []byte, err error) {
+	err = c.getContext(ctx, endpoint, params, func(body io.Reader) error {
+		data, err = io.ReadAll(body)
+		return err
+	})
+	return data, err
+}
+
+// decodeContext decodes the JSON response of endpoint into v as it is read,
+// without buffering the whole response.
+func (c *Client) decodeContext(ctx context.Context, endpoint string, params url.Values, v any) error {
+	return c.getContext(ctx, endpoint, params, func(body io.Reader) error {
+		return json.NewDecoder(body).Decode(v)
+	})
+}
+
+func (c *Client) getContext(ctx context.Context, endpoint string, params url.Values, read func(io.Reader) error) (err error) {
 	fullURL, err := url.JoinPath(c.BaseURL, endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("%w: build DiscoAPI URL: %w", ErrNetwork, err)
+		return fmt.Errorf("%w: build DiscoAPI URL: %w", ErrNetwork, err)
 	}
 	if params != nil && len(params) > 0 {
 		fullURL += "?" + params.Encode()
@@ -67,11 +84,11 @@ func (c *Client) fetchContext(ctx context.Context, endpoint string, params url.V
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("%w: build GET %s: %w", ErrNetwork, fullURL, err)
+		return fmt.Errorf("%w: build GET %s: %w", ErrNetwork, fullURL, err)
 	}
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("%w: GET %s: %w", ErrNetwork, fullURL, err)
+		return fmt.Errorf("%w: GET %s: %w", ErrNetwork, fullURL, err)
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
@@ -80,18 +97,53 @@ func (c *Client) fetchContext(ctx context.Context, endpoint string, params url.V
 	}()
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		return nil, fmt.Errorf("%w: GET %s returned %d", ErrNetwork, fullURL, resp.StatusCode)
+		return fmt.Errorf("%w: GET %s returned %d", ErrNetwork, fullURL, resp.StatusCode)
 	}
 
 	if resp.ContentLength > maxResponseSize {
-		return nil, fmt.Errorf("%w: GET %s response exceeds %d bytes", ErrNetwork, fullURL, maxResponseSize)
+		return fmt.Errorf("%w: GET %s response exceeds %d bytes", ErrNetwork, fullURL, maxResponseSize)
 	}
-	data, err = io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
-	if err != nil {
-		return nil, fmt.Errorf("%w: read GET %s response: %w", ErrNetwork, fullURL, err)
+	body := &sizeLimitedReader{reader: resp.Body, remaining: maxResponseSize}
+	if err := read(body); err != nil {
+		if body.exceeded {
+			return fmt.Errorf("%w: GET %s response exceeds %d bytes", ErrNetwork, fullURL, maxResponseSize)
+		}
+		if body.readErr != nil {
+			return fmt.Errorf("%w: read GET %s response: %w", ErrNetwork, fullURL, body.readErr)
+		}
+		return err
+	}
+	return nil
+}
+
+var errResponseTooLarge = errors.New("response too large")
+
+// sizeLimitedReader fails once more than remaining bytes are read, so an
+// oversized response is reported instead of being silently truncated. It
+// remembers read failures to tell them apart from decoding errors.
+type sizeLimitedReader struct {
+	reader    io.Reader
+	remaining int64
+	exceeded  bool
+	readErr   error
+}
+
+func (r *sizeLimitedReader) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		r.exceeded = true
+		return 0, errResponseTooLarge
+	}
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		r.exceeded = true
+		return n, errResponseTooLarge
 	}
-	if int64(len(data)) > maxResponseSize {
-		return nil, fmt.Errorf("%w: GET %s response exceeds %d bytes", ErrNetwork, fullURL, maxResponseSize)
+	if err != nil && err != io.EOF {
+		r.readErr = err
 	}
-	return data, nil
+	return n, err
 }
@@ -127,3 +127,46 @@ func TestClientFetchRejectsOversizedAndHonorsContext(t *testing.T) {
 		}
 	})
 }
+
+func TestClientDecodeContext(t *testing.T) {
+	t.Run("decodes the response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			io.WriteString(w, `{"ok": "yes"}`)
+		}))
+		defer server.Close()
+		client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		var got struct{ OK string }
+		if err := client.decodeContext(context.Background(), "endpoint", nil, &got); err != nil || got.OK != "yes" {
+			t.Fatalf("decodeContext = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("oversized chunked response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.(http.Flusher).Flush()
+			io.WriteString(w, `{"ok": "`)
+			_, _ = io.CopyN(w, strings.NewReader(strings.Repeat("x", int(maxResponseSize))), maxResponseSize)
+			io.WriteString(w, `"}`)
+		}))
+		defer server.Close()
+		client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		var got struct{ OK string }
+		err := client.decodeContext(context.Background(), "large", nil, &got)
+		if !errors.Is(err, ErrNetwork) || !strings.Contains(err.Error(), "exceeds") {
+			t.Fatalf("expected response limit error, got %v", err)
+		}
+	})
+
+	t.Run("malformed JSON is not a network error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			io.WriteString(w, `{"ok": `)
+		}))
+		defer server.Close()
+		client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+		var got struct{ OK string }
+		err := client.decodeContext(context.Background(), "broken", nil, &got)
+		if err == nil || errors.Is(err, ErrNetwork) {
+			t.Fatalf("expected a decoding error, got %v", err)
+		}
+	})
+}
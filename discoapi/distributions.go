@@ -2,10 +2,11 @@ package discoapi
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -14,15 +15,23 @@ func (c *Client) GetDistributions() ([]Distribution, error) {
 }
 
 func (c *Client) GetDistributionsContext(ctx context.Context) ([]Distribution, error) {
-	params := url.Values{}
-	params.Set("include_versions", "false")
-	data, err := c.fetchContext(ctx, "distributions", params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch distributions: %w", err)
-	}
+	return c.getDistributions(ctx, false)
+}
 
+// GetDistributionDetailsContext lists the distributions together with every
+// version they publish.
+func (c *Client) GetDistributionDetailsContext(ctx context.Context) ([]Distribution, error) {
+	return c.getDistributions(ctx, true)
+}
+
+func (c *Client) getDistributions(ctx context.Context, includeVersions bool) ([]Distribution, error) {
+	params := url.Values{}
+	params.Set("include_versions", strconv.FormatBool(includeVersions))
 	var response DistributionsResponse
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := c.decodeContext(ctx, "distributions", params, &response); err != nil {
+		if errors.Is(err, ErrNetwork) {
+			return nil, fmt.Errorf("failed to fetch distributions: %w", err)
+		}
 		return nil, fmt.Errorf("failed to parse distributions: %w", err)
 	}
 
@@ -75,3 +75,24 @@ func TestGetDistributionsHonorsContext(t *testing.T) {
 		t.Fatalf("expected deadline error, got %v", err)
 	}
 }
+
+func TestGetDistributionDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include_versions") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		io.WriteString(w, `{"result": [{"name": "Temurin", "api_parameter": "temurin",
+			"synonyms": ["temurin", "adoptium", "Temurin"], "versions": ["21.0.4+7", "17.0.12+7"]}]}`)
+	}))
+	defer server.Close()
+	client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	dists, err := client.GetDistributionDetailsContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dists) != 1 || len(dists[0].Synonyms) != 3 || dists[0].Synonyms[1] != "adoptium" || len(dists[0].Versions) != 2 {
+		t.Fatalf("unexpected distributions: %+v", dists)
+	}
+}
@@ -0,0 +1,30 @@
+package discoapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+func (c *Client) GetMajorVersions() ([]MajorVersion, error) {
+	return c.GetMajorVersionsContext(context.Background())
+}
+
+// GetMajorVersionsContext lists the generally available Java feature
+// releases, newest first, with their support term and maintenance status.
+func (c *Client) GetMajorVersionsContext(ctx context.Context) ([]MajorVersion, error) {
+	params := url.Values{}
+	params.Set("ea", "false")
+	params.Set("ga", "true")
+	params.Set("include_versions", "true")
+
+	var response MajorVersionsResponse
+	if err := c.decodeContext(ctx, "major_versions", params, &response); err != nil {
+		if errors.Is(err, ErrNetwork) {
+			return nil, fmt.Errorf("failed to fetch major versions: %w", err)
+		}
+		return nil, fmt.Errorf("failed to parse major versions: %w", err)
+	}
+	return response.MajorVersions, nil
+}
@@ -0,0 +1,69 @@
+package discoapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const mockMajorVersionsResponse = `{
+  "result": [
+    {
+      "major_version": 22,
+      "term_of_support": "STS",
+      "maintained": false,
+      "early_access_only": false,
+      "release_status": "ga",
+      "versions": ["22.0.2+9", "22.0.1+8", "22+36"]
+    },
+    {
+      "major_version": 21,
+      "term_of_support": "LTS",
+      "maintained": true,
+      "early_access_only": false,
+      "release_status": "ga",
+      "versions": ["21.0.4+7", "21.0.3+9"]
+    }
+  ]
+}`
+
+func TestGetMajorVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/major_versions" || query.Get("ga") != "true" || query.Get("ea") != "false" || query.Get("include_versions") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		io.WriteString(w, mockMajorVersionsResponse)
+	}))
+	defer server.Close()
+	client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	majors, err := client.GetMajorVersions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(majors) != 2 {
+		t.Fatalf("expected 2 major versions, got %d", len(majors))
+	}
+	if m := majors[0]; m.MajorVersion != 22 || m.IsLTS() || m.Maintained || len(m.Versions) != 3 {
+		t.Errorf("unexpected first major version: %+v", m)
+	}
+	if m := majors[1]; m.MajorVersion != 21 || !m.IsLTS() || !m.Maintained || m.Versions[0] != "21.0.4+7" {
+		t.Errorf("unexpected second major version: %+v", m)
+	}
+}
+
+func TestGetMajorVersionsReportsNetworkErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	if _, err := client.GetMajorVersionsContext(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -1,8 +1,15 @@
 package discoapi
 
+import "strings"
+
 type Distribution struct {
 	Name         string `json:"name"`
 	APIParameter string `json:"api_parameter"`
+	// Synonyms lists other names DiscoAPI accepts for the distribution.
+	Synonyms []string `json:"synonyms,omitempty"`
+	// Versions lists the published releases. It is only filled in by
+	// GetDistributionDetailsContext.
+	Versions []string `json:"versions,omitempty"`
 }
 
 type DistributionsResponse struct {
@@ -10,16 +17,50 @@ type DistributionsResponse struct {
 }
 
 type Package struct {
-	Id                  string `json:"id"`
-	Distribution        string `json:"distribution"`
-	JavaVersion         string `json:"java_version"`
-	DistributionVersion string `json:"distribution_version"`
+	Id                   string `json:"id"`
+	Distribution         string `json:"distribution"`
+	MajorVersion         int    `json:"major_version"`
+	JavaVersion          string `json:"java_version"`
+	DistributionVersion  string `json:"distribution_version"`
+	ArchiveType          string `json:"archive_type"`
+	LibCType             string `json:"lib_c_type"`
+	TermOfSupport        string `json:"term_of_support"`
+	ReleaseStatus        string `json:"release_status"`
+	LatestBuildAvailable bool   `json:"latest_build_available"`
+	JavaFXBundled        bool   `json:"javafx_bundled"`
+	Size                 int64  `json:"size"`
+	ReleaseDate          string `json:"release_date"`
+}
+
+// IsLTS reports whether the package belongs to a long-term support release.
+func (p Package) IsLTS() bool {
+	return strings.EqualFold(p.TermOfSupport, "lts")
 }
 
 type PackagesResponse struct {
 	Packages []Package `json:"result"`
 }
 
+// MajorVersion is a Java feature release, such as 21, and its lifecycle.
+type MajorVersion struct {
+	MajorVersion    int    `json:"major_version"`
+	TermOfSupport   string `json:"term_of_support"`
+	Maintained      bool   `json:"maintained"`
+	EarlyAccessOnly bool   `json:"early_access_only"`
+	ReleaseStatus   string `json:"release_status"`
+	// Versions lists the published builds of the release.
+	Versions []string `json:"versions"`
+}
+
+// IsLTS reports whether the release is a long-term support release.
+func (m MajorVersion) IsLTS() bool {
+	return strings.EqualFold(m.TermOfSupport, "lts")
+}
+
+type MajorVersionsResponse struct {
+	MajorVersions []MajorVersion `json:"result"`
+}
+
 type PackageInfo struct {
 	Filename          string `json:"filename"`
 	DirectDownloadUri string `json:"direct_download_uri"`
@@ -2,49 +2,89 @@ package discoapi
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"slices"
+	"strconv"
 )
 
-func (c *Client) GetPackages(os, arch, distribution, version string) ([]Package, error) {
-	return c.GetPackagesContext(context.Background(), os, arch, distribution, version)
+// PackageQuery selects the JDK packages returned by GetPackagesContext.
+type PackageQuery struct {
+	OS           string
+	Arch         string
+	Distribution string
+	Version      string
+	// LibC forces the Linux C library ("glibc" or "musl"). When it is empty
+	// or "auto", the C library of the host is detected.
+	LibC string
+	// ArchiveTypes lists the accepted archive types in order of preference.
+	// When it is empty, DefaultArchiveTypes for OS is used.
+	ArchiveTypes []string
+	// JDKVersion limits the result to one feature release, e.g. 21. Zero
+	// means any.
+	JDKVersion uint64
+	// LatestOnly asks DiscoAPI for the newest package of each distribution
+	// instead of every release.
+	LatestOnly bool
 }
 
-func (c *Client) GetPackagesContext(ctx context.Context, os, arch, distribution, version string) ([]Package, error) {
-	archFilter := map[string]string{
-		"amd64": "amd64,x64",
-		"arm64": "arm64,aarch64",
-	}[arch]
+// packagesPageSize is the number of packages requested per page.
+var packagesPageSize = 500
+
+// maxPackagePages stops paging through servers that keep returning full pages.
+const maxPackagePages = 100
+
+// SupportedArchiveTypes lists the archive types javm can extract.
+var SupportedArchiveTypes = []string{"tar.gz", "tgz", "tar.xz", "zip"}
+
+// DefaultArchiveTypes returns the archive types accepted for goos, most
+// preferred first.
+func DefaultArchiveTypes(goos string) []string {
+	if goos == "windows" {
+		return []string{"zip", "tar.gz", "tgz", "tar.xz"}
+	}
+	return slices.Clone(SupportedArchiveTypes)
+}
+
+func (c *Client) GetPackages(os, arch, distribution, version string) ([]Package, error) {
+	return c.GetPackagesContext(context.Background(), PackageQuery{OS: os, Arch: arch, Distribution: distribution, Version: version})
+}
 
+func (c *Client) GetPackagesContext(ctx context.Context, query PackageQuery) ([]Package, error) {
 	params := url.Values{}
-	if os != "" {
-		params.Set("operating_system", os)
+	if query.OS != "" {
+		params.Set("operating_system", query.OS)
 	}
-	if arch != "" {
-		params.Set("architecture", archFilter)
+	if query.Arch != "" {
+		params.Set("architecture", architectureFilter(query.Arch))
 	}
-	if distribution != "" {
-		params.Set("distribution", distribution)
+	if query.Distribution != "" {
+		params.Set("distribution", query.Distribution)
 	}
-	if version != "" {
-		params.Set("version", version)
+	if query.Version != "" {
+		params.Set("version", query.Version)
+	}
+	if query.JDKVersion > 0 {
+		params.Set("jdk_version", strconv.FormatUint(query.JDKVersion, 10))
+	}
+	if query.LatestOnly {
+		params.Set("latest", "per_distro")
+	}
+
+	archiveTypes := query.ArchiveTypes
+	if len(archiveTypes) == 0 {
+		archiveTypes = DefaultArchiveTypes(query.OS)
+	}
+	for _, archiveType := range archiveTypes {
+		params.Add("archive_type", archiveType)
 	}
 
-	if os == "windows" {
-		params.Set("archive_type", "zip")
+	if query.OS == "windows" {
 		params.Set("lib_c_type", "c_std_lib")
 	} else {
-		params.Set("archive_type", "tar.gz")
-
-		libc := "glibc"
-		if os == "linux" && isMuslLibc() {
-			libc = "musl"
-		} else if os == "darwin" {
-			libc = "libc"
-		}
-
-		c.logger().Debugf("OS is %s, libc is %s", os, libc)
+		libc, evidence := libcType(query.OS, query.LibC)
+		c.logger().Debugf("OS is %s, libc is %s (%s)", query.OS, libc, evidence)
 		params.Set("lib_c_type", libc)
 	}
 
@@ -52,17 +92,79 @@ func (c *Client) GetPackagesContext(ctx context.Context, os, arch, distribution,
 	params.Set("release_status", "ga")
 
 	c.logger().Debugf("fetching packages with params: %s", params.Encode())
-	data, err := c.fetchContext(ctx, "packages", params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch packages: %w", err)
+	var packages []Package
+	var firstOfPreviousPage string
+	for page := 1; page <= maxPackagePages; page++ {
+		params.Set("page", strconv.Itoa(page))
+		params.Set("page_size", strconv.Itoa(packagesPageSize))
+		var resp PackagesResponse
+		if err := c.decodeContext(ctx, "packages", params, &resp); err != nil {
+			if errors.Is(err, ErrNetwork) {
+				return nil, fmt.Errorf("failed to fetch packages: %w", err)
+			}
+			return nil, fmt.Errorf("failed to parse packages: %w", err)
+		}
+		if len(resp.Packages) == 0 || resp.Packages[0].Id == firstOfPreviousPage {
+			// An empty page ends the list; a repeated one means the
+			// server ignores paging and already sent everything.
+			break
+		}
+		packages = append(packages, resp.Packages...)
+		if len(resp.Packages) != packagesPageSize {
+			break
+		}
+		firstOfPreviousPage = resp.Packages[0].Id
 	}
 
-	var resp PackagesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse packages: %w", err)
+	return preferArchiveTypes(packages, archiveTypes), nil
+}
+
+// preferArchiveTypes keeps a single package per build, choosing the archive
+// type that comes first in order. The position of the first package of each
+// build is preserved.
+func preferArchiveTypes(pkgs []Package, order []string) []Package {
+	rank := func(pkg Package) int {
+		if i := slices.Index(order, pkg.ArchiveType); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+	type build struct{ distribution, javaVersion, distributionVersion string }
+	index := make(map[build]int, len(pkgs))
+	result := make([]Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		key := build{pkg.Distribution, pkg.JavaVersion, pkg.DistributionVersion}
+		if i, ok := index[key]; ok {
+			if rank(pkg) < rank(result[i]) {
+				result[i] = pkg
+			}
+			continue
+		}
+		index[key] = len(result)
+		result = append(result, pkg)
 	}
+	return result
+}
+
+// C library names accepted by PackageQuery.LibC.
+const (
+	LibCAuto  = "auto"
+	LibCGlibc = "glibc"
+	LibCMusl  = "musl"
+)
 
-	return resp.Packages, nil
+// libcType returns the DiscoAPI lib_c_type for goos and explains how it was chosen.
+func libcType(goos, override string) (string, string) {
+	switch goos {
+	case "windows":
+		return "c_std_lib", "Windows C runtime"
+	case "darwin":
+		return "libc", "macOS system library"
+	}
+	if override != "" && override != LibCAuto {
+		return override, "explicitly requested"
+	}
+	return DetectLibC()
 }
 
 func (c *Client) GetPackageInfo(id string) (*PackageInfo, error) {
@@ -70,13 +172,11 @@ func (c *Client) GetPackageInfo(id string) (*PackageInfo, error) {
 }
 
 func (c *Client) GetPackageInfoContext(ctx context.Context, id string) (*PackageInfo, error) {
-	data, err := c.fetchContext(ctx, "ids/"+id, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch package info: %w", err)
-	}
-
 	var resp PackageInfoResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.decodeContext(ctx, "ids/"+id, nil, &resp); err != nil {
+		if errors.Is(err, ErrNetwork) {
+			return nil, fmt.Errorf("failed to fetch package info: %w", err)
+		}
 		return nil, fmt.Errorf("failed to parse package info: %w", err)
 	}
 	if len(resp.PackageInfo) == 0 {
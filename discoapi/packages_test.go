@@ -1,12 +1,17 @@
 package discoapi
 
 import (
+	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"strings"
 	"testing"
+
+	log "github.com/sirupsen/logrus"
 )
 
 // Sample JSON response similar to what DiscoAPI would return
@@ -15,8 +20,17 @@ const mockPackagesResponse = `{
     {
       "id": "50f16d2dc2bb80a421afc1af38fc92e3",
       "distribution": "temurin",
+      "major_version": 24,
       "java_version": "24.0.2+12",
-      "distribution_version": "24.0.2"
+      "distribution_version": "24.0.2",
+      "archive_type": "tar.gz",
+      "lib_c_type": "glibc",
+      "term_of_support": "sts",
+      "release_status": "ga",
+      "latest_build_available": true,
+      "javafx_bundled": false,
+      "size": 206126467,
+      "release_date": "2025-07-15T00:00:00Z"
     },
     {
       "id": "4b983e5b6800eee4023259bd42e03844",
@@ -56,6 +70,29 @@ func TestGetPackages_QueryParams(t *testing.T) {
 				{"release_status", "ga"},
 			},
 		},
+		{
+			name: "linux s390x semeru 21",
+			os:   "linux",
+			arch: "s390x",
+			dist: "semeru",
+			ver:  "21",
+			expect: []wantParam{
+				{"operating_system", "linux"},
+				{"architecture", "s390x"},
+				{"distribution", "semeru"},
+				{"version", "21"},
+			},
+		},
+		{
+			name: "linux ppc64le temurin 17",
+			os:   "linux",
+			arch: "ppc64le",
+			dist: "temurin",
+			ver:  "17",
+			expect: []wantParam{
+				{"architecture", "ppc64le,ppc64el"},
+			},
+		},
 		{
 			name: "windows arm64 zulu 24",
 			os:   "windows",
@@ -109,6 +146,10 @@ func TestGetPackages_QueryParams(t *testing.T) {
 			if packages[0].Id != "50f16d2dc2bb80a421afc1af38fc92e3" || packages[0].Distribution != "temurin" || packages[0].JavaVersion != "24.0.2+12" || packages[0].DistributionVersion != "24.0.2" {
 				t.Errorf("unexpected first package: %+v", packages[0])
 			}
+			if first := packages[0]; first.MajorVersion != 24 || first.TermOfSupport != "sts" || first.IsLTS() || !first.LatestBuildAvailable ||
+				first.Size != 206126467 || first.LibCType != "glibc" || first.ReleaseStatus != "ga" || first.ReleaseDate != "2025-07-15T00:00:00Z" {
+				t.Errorf("unexpected first package metadata: %+v", first)
+			}
 			if packages[1].Id != "4b983e5b6800eee4023259bd42e03844" || packages[1].Distribution != "temurin" || packages[1].JavaVersion != "24+36" || packages[1].DistributionVersion != "24" {
 				t.Errorf("unexpected second package: %+v", packages[1])
 			}
@@ -116,6 +157,93 @@ func TestGetPackages_QueryParams(t *testing.T) {
 	}
 }
 
+func TestGetPackagesContext_LibCOverride(t *testing.T) {
+	var gotParams url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotParams = r.URL.Query()
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, mockPackagesResponse)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&logs)
+	logger.SetLevel(log.DebugLevel)
+	client := &Client{BaseURL: server.URL, HTTPClient: server.Client(), Logger: logger}
+
+	query := PackageQuery{OS: "linux", Arch: "amd64", Distribution: "temurin", LibC: LibCMusl}
+	if _, err := client.GetPackagesContext(context.Background(), query); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := gotParams.Get("lib_c_type"); got != "musl" {
+		t.Errorf("lib_c_type = %q, want musl", got)
+	}
+	if !strings.Contains(logs.String(), "libc is musl (explicitly requested)") {
+		t.Errorf("debug output does not explain the libc choice: %q", logs.String())
+	}
+}
+
+func TestGetPackagesContext_ArchiveTypes(t *testing.T) {
+	const response = `{"result": [
+		{"id": "a", "distribution": "zulu", "java_version": "21.0.2+13", "distribution_version": "21.32.17", "archive_type": "zip"},
+		{"id": "b", "distribution": "zulu", "java_version": "17.0.10+7", "distribution_version": "17.48.15", "archive_type": "tar.xz"},
+		{"id": "c", "distribution": "zulu", "java_version": "21.0.2+13", "distribution_version": "21.32.17", "archive_type": "tar.gz"},
+		{"id": "d", "distribution": "zulu", "java_version": "21.0.2+13", "distribution_version": "21.32.17", "archive_type": "tgz"}
+	]}`
+	var gotParams url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotParams = r.URL.Query()
+		io.WriteString(w, response)
+	}))
+	defer server.Close()
+	client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	tests := []struct {
+		name      string
+		query     PackageQuery
+		wantTypes []string
+		wantIDs   []string
+	}{
+		{
+			name:      "default order",
+			query:     PackageQuery{OS: "linux", LibC: LibCGlibc},
+			wantTypes: []string{"tar.gz", "tgz", "tar.xz", "zip"},
+			wantIDs:   []string{"c", "b"},
+		},
+		{
+			name:      "windows default order",
+			query:     PackageQuery{OS: "windows"},
+			wantTypes: []string{"zip", "tar.gz", "tgz", "tar.xz"},
+			wantIDs:   []string{"a", "b"},
+		},
+		{
+			name:      "configured order",
+			query:     PackageQuery{OS: "linux", LibC: LibCGlibc, ArchiveTypes: []string{"tgz", "tar.xz"}},
+			wantTypes: []string{"tgz", "tar.xz"},
+			wantIDs:   []string{"d", "b"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pkgs, err := client.GetPackagesContext(context.Background(), tc.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := gotParams["archive_type"]; !slices.Equal(got, tc.wantTypes) {
+				t.Errorf("archive_type = %v, want %v", got, tc.wantTypes)
+			}
+			var ids []string
+			for _, pkg := range pkgs {
+				ids = append(ids, pkg.Id)
+			}
+			if !slices.Equal(ids, tc.wantIDs) {
+				t.Errorf("packages = %v, want %v", ids, tc.wantIDs)
+			}
+		})
+	}
+}
+
 const mockPackageInfoResponse = `{
   "result": [
     {
@@ -205,3 +333,77 @@ func TestGetPackageInfoRejectsEmptyResult(t *testing.T) {
 		t.Fatalf("expected empty package info error, got %v", err)
 	}
 }
+
+func TestGetPackagesContext_Pagination(t *testing.T) {
+	oldPageSize := packagesPageSize
+	packagesPageSize = 2
+	t.Cleanup(func() { packagesPageSize = oldPageSize })
+
+	pages := map[string]string{
+		"1": `{"result": [{"id": "a", "java_version": "21.0.3"}, {"id": "b", "java_version": "21.0.2"}]}`,
+		"2": `{"result": [{"id": "c", "java_version": "21.0.1"}]}`,
+	}
+	t.Run("follows pages until a short one", func(t *testing.T) {
+		var requested []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			page := r.URL.Query().Get("page")
+			requested = append(requested, page)
+			if got := r.URL.Query().Get("page_size"); got != "2" {
+				t.Errorf("page_size = %q, want 2", got)
+			}
+			io.WriteString(w, pages[page])
+		}))
+		defer server.Close()
+		client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+		pkgs, err := client.GetPackagesContext(context.Background(), PackageQuery{OS: "windows", JDKVersion: 21, LatestOnly: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pkgs) != 3 || !slices.Equal(requested, []string{"1", "2"}) {
+			t.Fatalf("got %d packages from pages %v, want 3 from [1 2]", len(pkgs), requested)
+		}
+	})
+
+	t.Run("stops when the server ignores paging", func(t *testing.T) {
+		var requests int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			io.WriteString(w, pages["1"])
+		}))
+		defer server.Close()
+		client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+		pkgs, err := client.GetPackagesContext(context.Background(), PackageQuery{OS: "windows"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pkgs) != 2 || requests != 2 {
+			t.Fatalf("got %d packages in %d requests, want 2 in 2", len(pkgs), requests)
+		}
+	})
+}
+
+func TestGetPackagesContext_ServerSideFilters(t *testing.T) {
+	var gotParams url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotParams = r.URL.Query()
+		io.WriteString(w, `{"result": []}`)
+	}))
+	defer server.Close()
+	client := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	if _, err := client.GetPackagesContext(context.Background(), PackageQuery{OS: "windows", JDKVersion: 21, LatestOnly: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotParams.Get("jdk_version") != "21" || gotParams.Get("latest") != "per_distro" {
+		t.Errorf("jdk_version = %q, latest = %q; want 21 and per_distro", gotParams.Get("jdk_version"), gotParams.Get("latest"))
+	}
+
+	if _, err := client.GetPackagesContext(context.Background(), PackageQuery{OS: "windows"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotParams.Has("jdk_version") || gotParams.Has("latest") {
+		t.Errorf("unexpected filters in %s", gotParams.Encode())
+	}
+}
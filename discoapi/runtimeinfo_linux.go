@@ -2,10 +2,13 @@ package discoapi
 
 import (
 	"bytes"
+	"debug/elf"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"slices"
+	"strings"
 	"syscall"
 )
 
@@ -18,23 +21,85 @@ var fileExistsFn = func(p string) bool {
 
 var safeLddVersionFn = safeLddVersion
 
-func isMuslLibc() bool {
+var elfInterpreterFn = elfInterpreter
+
+// interpreterProbes are dynamically linked executables whose program
+// interpreter reveals the C library. javm itself is usually static, so /bin/sh
+// is the probe that answers on most systems.
+var interpreterProbes = []string{
+	"/proc/self/exe",
+	"/bin/sh",
+}
+
+// DetectLibC reports the C library of the host, "glibc" or "musl", and how it
+// was determined. The ELF program interpreter is authoritative; loader paths
+// and ldd are only consulted when no probe is dynamically linked, such as in
+// distroless images.
+func DetectLibC() (string, string) {
+	for _, probe := range interpreterProbes {
+		interpreter, err := elfInterpreterFn(probe)
+		if err != nil || interpreter == "" {
+			continue
+		}
+		if libc, ok := libcFromInterpreter(interpreter); ok {
+			return libc, fmt.Sprintf("ELF interpreter %s of %s", interpreter, probe)
+		}
+	}
+
 	if looksLikeMuslFilesystem() {
-		return true
+		return LibCMusl, "musl loader found"
 	}
 
 	if looksLikeGlibcFilesystem() {
-		return false
+		return LibCGlibc, "glibc loader found"
 	}
 
 	out, ok := safeLddVersionFn()
 	if ok {
 		if bytes.Contains(out, []byte("musl libc")) {
-			return true
+			return LibCMusl, "ldd --version"
 		}
+		return LibCGlibc, "ldd --version"
 	}
 
-	return false
+	return LibCGlibc, "default, detection was inconclusive"
+}
+
+// elfInterpreter returns the PT_INTERP path of the ELF file at path, or an
+// empty string for statically linked executables.
+func elfInterpreter(path string) (string, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	for _, prog := range f.Progs {
+		if prog.Type != elf.PT_INTERP {
+			continue
+		}
+		if prog.Filesz > 4096 {
+			return "", fmt.Errorf("ELF interpreter of %s is too long", path)
+		}
+		data := make([]byte, prog.Filesz)
+		if _, err := prog.ReadAt(data, 0); err != nil {
+			return "", fmt.Errorf("read ELF interpreter of %s: %w", path, err)
+		}
+		return string(bytes.TrimRight(data, "\x00")), nil
+	}
+	return "", nil
+}
+
+func libcFromInterpreter(interpreter string) (string, bool) {
+	name := filepath.Base(interpreter)
+	switch {
+	case strings.HasPrefix(name, "ld-musl-"):
+		return LibCMusl, true
+	case strings.HasPrefix(name, "ld-linux"), strings.HasPrefix(name, "ld64.so."), strings.HasPrefix(name, "ld.so."):
+		return LibCGlibc, true
+	default:
+		return "", false
+	}
 }
 
 func looksLikeMuslFilesystem() bool {
@@ -3,6 +3,8 @@ package discoapi
 import (
 	"errors"
 	"os"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -17,6 +19,11 @@ func withMocks(t *testing.T,
 	origFileExists := fileExistsFn
 	origSafeLdd := safeLddVersionFn
 	origStatFn := statFn
+	origElfInterpreter := elfInterpreterFn
+
+	elfInterpreterFn = func(string) (string, error) {
+		return "", errors.New("no ELF probes in test")
+	}
 
 	// mock fileExistsFn
 	if mockFileExists != nil {
@@ -40,6 +47,7 @@ func withMocks(t *testing.T,
 		fileExistsFn = origFileExists
 		safeLddVersionFn = origSafeLdd
 		statFn = origStatFn
+		elfInterpreterFn = origElfInterpreter
 	}
 }
 
@@ -60,7 +68,7 @@ func (f fakeFileInfo) Sys() any           { return f.sys }
 // Test cases
 //
 
-func TestIsMuslLibc_MuslFastPath(t *testing.T) {
+func TestDetectLibC_MuslFastPath(t *testing.T) {
 	cleanup := withMocks(t,
 		func(p string) bool {
 			return p == "/lib/ld-musl-x86_64.so.1"
@@ -72,12 +80,12 @@ func TestIsMuslLibc_MuslFastPath(t *testing.T) {
 	)
 	defer cleanup()
 
-	if !isMuslLibc() {
+	if libc, _ := DetectLibC(); libc != LibCMusl {
 		t.Errorf("expected true (musl fast-path)")
 	}
 }
 
-func TestIsMuslLibc_GlibcFastPath(t *testing.T) {
+func TestDetectLibC_GlibcFastPath(t *testing.T) {
 	cleanup := withMocks(t,
 		func(p string) bool {
 			return p == "/lib64/ld-linux-x86-64.so.2"
@@ -89,12 +97,12 @@ func TestIsMuslLibc_GlibcFastPath(t *testing.T) {
 	)
 	defer cleanup()
 
-	if isMuslLibc() {
+	if libc, _ := DetectLibC(); libc != LibCGlibc {
 		t.Errorf("expected false (glibc fast-path)")
 	}
 }
 
-func TestIsMuslLibc_LddFallback_Musl(t *testing.T) {
+func TestDetectLibC_LddFallback_Musl(t *testing.T) {
 	cleanup := withMocks(t,
 		func(p string) bool {
 			return false
@@ -105,12 +113,12 @@ func TestIsMuslLibc_LddFallback_Musl(t *testing.T) {
 	)
 	defer cleanup()
 
-	if !isMuslLibc() {
+	if libc, _ := DetectLibC(); libc != LibCMusl {
 		t.Errorf("expected true (musl via ldd fallback)")
 	}
 }
 
-func TestIsMuslLibc_LddFallback_Glibc(t *testing.T) {
+func TestDetectLibC_LddFallback_Glibc(t *testing.T) {
 	cleanup := withMocks(t,
 		func(p string) bool {
 			return false
@@ -121,12 +129,12 @@ func TestIsMuslLibc_LddFallback_Glibc(t *testing.T) {
 	)
 	defer cleanup()
 
-	if isMuslLibc() {
+	if libc, _ := DetectLibC(); libc != LibCGlibc {
 		t.Errorf("expected false (glibc via ldd fallback)")
 	}
 }
 
-func TestIsMuslLibc_Inconclusive(t *testing.T) {
+func TestDetectLibC_Inconclusive(t *testing.T) {
 	cleanup := withMocks(t,
 		func(p string) bool {
 			return false
@@ -137,7 +145,68 @@ func TestIsMuslLibc_Inconclusive(t *testing.T) {
 	)
 	defer cleanup()
 
-	if isMuslLibc() {
+	if libc, _ := DetectLibC(); libc != LibCGlibc {
 		t.Errorf("expected false (inconclusive defaults to false)")
 	}
 }
+
+func TestDetectLibC_ElfInterpreter(t *testing.T) {
+	tests := []struct {
+		name        string
+		interpreter map[string]string
+		want        string
+	}{
+		{
+			name:        "static javm falls through to musl shell",
+			interpreter: map[string]string{"/bin/sh": "/lib/ld-musl-s390x.so.1"},
+			want:        LibCMusl,
+		},
+		{
+			name:        "glibc on ppc64le",
+			interpreter: map[string]string{"/proc/self/exe": "/lib64/ld64.so.2"},
+			want:        LibCGlibc,
+		},
+		{
+			name:        "glibc on riscv64",
+			interpreter: map[string]string{"/bin/sh": "/lib/ld-linux-riscv64-lp64d.so.1"},
+			want:        LibCGlibc,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanup := withMocks(t,
+				func(string) bool {
+					t.Fatal("loader paths should not be probed when the ELF interpreter is known")
+					return false
+				},
+				func() ([]byte, bool) {
+					t.Fatal("ldd should not be called when the ELF interpreter is known")
+					return nil, false
+				},
+			)
+			defer cleanup()
+			elfInterpreterFn = func(path string) (string, error) {
+				return tt.interpreter[path], nil
+			}
+
+			libc, evidence := DetectLibC()
+			if libc != tt.want {
+				t.Errorf("DetectLibC() = %q, want %q", libc, tt.want)
+			}
+			if !strings.Contains(evidence, "ELF interpreter") {
+				t.Errorf("evidence = %q, want ELF interpreter", evidence)
+			}
+		})
+	}
+}
+
+func TestElfInterpreterRejectsNonELF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "script")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := elfInterpreter(path); err == nil {
+		t.Fatal("expected error for non-ELF file")
+	}
+}
@@ -2,6 +2,8 @@
 
 package discoapi
 
-func isMuslLibc() bool {
-	return false
+// DetectLibC reports the C library assumed for Linux packages when javm runs
+// on another operating system, where there is no host C library to inspect.
+func DetectLibC() (string, string) {
+	return LibCGlibc, "host is not Linux"
 }
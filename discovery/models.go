@@ -19,6 +19,12 @@ type JDK struct {
 	Architecture string `json:"architecture"`
 	Source       string `json:"source"`
 	Identifier   string `json:"identifier"`
+	// LTS is set when the release file marks the JDK as a long-term support
+	// release.
+	LTS bool `json:"lts,omitempty"`
+	// DistributionVersion is the vendor's own version number, such as
+	// "21.32.17" for Zulu, taken from IMPLEMENTOR_VERSION.
+	DistributionVersion string `json:"distribution_version,omitempty"`
 }
 
 // DiscoveryWarning describes a non-fatal failure while discovering JDKs.
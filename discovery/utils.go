@@ -10,7 +10,10 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
+
+	"github.com/felipebz/javm/semver"
 )
 
 var identifierRegexp = regexp.MustCompile("[^a-z0-9]+")
@@ -137,6 +140,9 @@ func ValidateJDKContext(ctx context.Context, vfs fs.FS, runner Runner, root, p,
 		Vendor:       md["JAVA_VENDOR"],
 		Architecture: normalizeArchitecture(md["OS_ARCH"]),
 		Source:       source,
+		LTS:          isLTSRelease(md),
+
+		DistributionVersion: distributionVersion(md["IMPLEMENTOR_VERSION"]),
 	}
 
 	if result.Version == "" || result.Vendor == "" || result.Architecture == "" {
@@ -166,6 +172,27 @@ func ValidateJDKContext(ctx context.Context, vfs fs.FS, runner Runner, root, p,
 	return result, true, nil
 }
 
+// isLTSRelease reports whether release file metadata carries the "-LTS"
+// suffix vendors add to the runtime version of long-term support builds.
+func isLTSRelease(md map[string]string) bool {
+	for _, key := range []string{"JAVA_RUNTIME_VERSION", "IMPLEMENTOR_VERSION"} {
+		if strings.Contains(md[key], "-LTS") {
+			return true
+		}
+	}
+	return false
+}
+
+var implementorVersionRegexp = regexp.MustCompile(`\d+(?:[._+-]\d+)*`)
+
+// distributionVersion extracts the vendor version from IMPLEMENTOR_VERSION,
+// e.g. "21.32.17" from "Zulu21.32+17-CA" or "21.0.4.7.1" from
+// "Corretto-21.0.4.7.1".
+func distributionVersion(implementorVersion string) string {
+	version := implementorVersionRegexp.FindString(implementorVersion)
+	return strings.NewReplacer("+", ".", "_", ".", "-", ".").Replace(version)
+}
+
 func ExtractMetadataFromReleaseFile(vfs fs.FS, jdkDir string) (map[string]string, error) {
 	b, err := fs.ReadFile(vfs, path.Join(jdkDir, "release"))
 	if err != nil {
@@ -224,11 +251,30 @@ func DeduplicateJDKs(jdks []JDK) []JDK {
 	return result
 }
 
+// normalizeArchitecture maps the os.arch and OS_ARCH spellings used by JDK
+// vendors to the names DiscoAPI uses, so the same platform always reads alike.
 func normalizeArchitecture(arch string) string {
-	if arch == "x86_64" || arch == "amd64" {
+	arch = strings.ToLower(strings.TrimSpace(arch))
+	switch arch {
+	case "x86_64", "amd64", "x64":
 		return "x64"
+	case "aarch64", "arm64":
+		return "aarch64"
+	case "x86", "386", "i386", "i486", "i586", "i686":
+		return "x86"
+	case "arm", "arm32", "armv7", "armv7l", "armhf", "aarch32":
+		return "arm"
+	case "armv6", "armv6l":
+		return "armv6"
+	case "ppc64le", "ppc64el":
+		return "ppc64le"
+	case "loong64", "loongarch64":
+		return "loong64"
+	case "sparcv9", "sparc64":
+		return "sparcv9"
+	default:
+		return arch
 	}
-	return arch
 }
 
 func generateSystemIdentifier(vendor, version, source string) string {
@@ -240,17 +286,10 @@ func generateSystemIdentifier(vendor, version, source string) string {
 		v = source
 	}
 
-	major := version
-	if strings.HasPrefix(version, "1.") {
-		parts := strings.Split(version, ".")
-		if len(parts) > 1 {
-			major = parts[1]
-		}
-	} else {
-		parts := strings.Split(version, ".")
-		if len(parts) > 0 {
-			major = parts[0]
-		}
+	// 1.8.0_392 -> 8
+	major, _, _ := strings.Cut(version, ".")
+	if v, err := semver.ParseVersion(version); err == nil {
+		major = strconv.FormatUint(v.Major(), 10)
 	}
 
 	return fmt.Sprintf("%s-%s@%s", v, source, major)
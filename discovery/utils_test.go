@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"os"
 	"path"
+	"runtime"
 	"testing"
 	"testing/fstest"
 )
@@ -155,6 +156,25 @@ func TestValidateJDK(t *testing.T) {
 	}
 }
 
+func TestValidateJDKDetectsLTSMarker(t *testing.T) {
+	vfs := fstest.MapFS{}
+	jdkPath := createFakeJDK(t, vfs, ".", "temurin-21")
+	releasePath := path.Join(ExpectedJDKDir(jdkPath, runtime.GOOS), "release")
+	vfs[releasePath] = &fstest.MapFile{Data: []byte(`JAVA_VERSION="21.0.2"
+JAVA_RUNTIME_VERSION="21.0.2+13-LTS"
+IMPLEMENTOR="Eclipse Adoptium"
+JAVA_VENDOR="Eclipse Adoptium"
+OS_ARCH="x86_64"`)}
+
+	jdk, ok, err := ValidateJDK(vfs, fakeRunner{}, "", jdkPath, "test")
+	if !ok || err != nil {
+		t.Fatalf("ValidateJDK() = %v, %v", ok, err)
+	}
+	if !jdk.LTS {
+		t.Errorf("LTS = false, want true for a -LTS runtime version")
+	}
+}
+
 func TestValidateJDK_IdentifierGeneration(t *testing.T) {
 	vfs := fstest.MapFS{}
 
@@ -399,3 +419,52 @@ func TestDeduplicateJDKs(t *testing.T) {
 		t.Error("Should find the first occurrence of the duplicate JDK")
 	}
 }
+
+func TestNormalizeArchitecture(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"amd64", "x64"},
+		{"x86_64", "x64"},
+		{"aarch64", "aarch64"},
+		{"arm64", "aarch64"},
+		{"i386", "x86"},
+		{"i686", "x86"},
+		{"x86", "x86"},
+		{"arm", "arm"},
+		{"armv7l", "arm"},
+		{"aarch32", "arm"},
+		{"armv6l", "armv6"},
+		{"ppc64le", "ppc64le"},
+		{"ppc64el", "ppc64le"},
+		{"ppc64", "ppc64"},
+		{"s390x", "s390x"},
+		{"riscv64", "riscv64"},
+		{"RISCV64", "riscv64"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := normalizeArchitecture(tt.input); got != tt.want {
+				t.Errorf("normalizeArchitecture(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistributionVersion(t *testing.T) {
+	tests := map[string]string{
+		"Zulu21.32+17-CA":        "21.32.17",
+		"Corretto-21.0.4.7.1":    "21.0.4.7.1",
+		"Temurin-21.0.4+7":       "21.0.4.7",
+		"GraalVM CE 21.0.2+13.1": "21.0.2.13.1",
+		"":                       "",
+		"Homebrew":               "",
+	}
+	for input, want := range tests {
+		if got := distributionVersion(input); got != want {
+			t.Errorf("distributionVersion(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -3,7 +3,6 @@ module github.com/felipebz/javm
 go 1.27.0
 
 require (
-	github.com/Masterminds/semver/v3 v3.5.0
 	github.com/schollz/progressbar/v3 v3.19.1
 	github.com/sirupsen/logrus v1.10.1
 	github.com/spf13/cobra v1.10.2
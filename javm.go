@@ -98,6 +98,8 @@ func newRootCommand(app application) *cobra.Command {
 		command.NewLinkCommand(),
 		command.NewUnlinkCommand(),
 		command.NewUseCommand(),
+		command.NewExecCommand(),
+		command.NewEnvCommand(),
 		command.NewCurrentCommand(),
 		command.NewLsCommand(),
 		command.NewLsRemoteCommand(app.client),
@@ -105,10 +107,14 @@ func newRootCommand(app application) *cobra.Command {
 		command.NewAliasCommand(),
 		command.NewUnaliasCommand(),
 		command.NewLsDistributionsCommand(app.client),
+		command.NewLifecycleCommand(app.client),
 		command.NewWhichCommand(),
 		command.NewInitCommand(),
 		command.NewDiscoverCommand(),
 		command.NewDefaultCommand(),
+		command.NewPinCommand(app.client),
+		command.NewLocalCommand(app.client),
+		command.NewShimsCommand(),
 		command.NewConfigCommand(),
 	)
 	root.Flags().Bool("version", false, "version of javm")
@@ -142,6 +148,10 @@ func exitCode(err error) int {
 	if err == nil || errors.Is(err, pflag.ErrHelp) {
 		return exitSuccess
 	}
+	var status *command.ExitStatusError
+	if errors.As(err, &status) {
+		return status.Code
+	}
 
 	switch {
 	case errors.Is(err, context.Canceled):
@@ -159,6 +169,20 @@ func exitCode(err error) int {
 	}
 }
 
+// runShim runs tool for a shim, reporting only problems so that the output
+// stays the one of the tool.
+func runShim(logger *log.Logger, tool string) int {
+	logger.SetLevel(log.WarnLevel)
+	logger.SetOutput(os.Stderr)
+	ctx := command.WithRuntime(context.Background(), command.Runtime{Logger: logger, Err: os.Stderr})
+	err := command.RunShim(ctx, tool, os.Args[1:])
+	var status *command.ExitStatusError
+	if err != nil && !errors.As(err, &status) {
+		fmt.Fprintln(os.Stderr, "javm:", err)
+	}
+	return exitCode(err)
+}
+
 func isCobraUsageError(err error) bool {
 	message := err.Error()
 	for _, prefix := range []string{
@@ -187,6 +211,9 @@ func main() {
 	logger := log.New()
 	logger.SetFormatter(&simpleFormatter{})
 	logger.SetLevel(log.InfoLevel)
+	if tool, ok := command.ShimTool(os.Args[0]); ok {
+		os.Exit(runShim(logger, tool))
+	}
 	client := discoapi.NewClient()
 	client.Logger = logger
 	rootCmd = newRootCommand(application{out: os.Stdout, err: os.Stderr, logger: logger, client: client, terminal: isTerminalWriter})
@@ -31,6 +31,7 @@ func TestExitCodeMapsStableErrorClasses(t *testing.T) {
 		{name: "timeout", err: context.DeadlineExceeded, want: exitTimeout},
 		{name: "interrupted", err: context.Canceled, want: exitInterrupted},
 		{name: "help", err: pflag.ErrHelp, want: exitSuccess},
+		{name: "child exit status", err: &command.ExitStatusError{Code: 42}, want: 42},
 	}
 
 	for _, tt := range tests {
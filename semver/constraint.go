@@ -0,0 +1,234 @@
+package semver
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// constraintRegexp matches one comparison of a range, such as ">=21.0.2+13",
+// "~1.8", "^17" or "21.x". Version numbers after a wildcard are ignored.
+var constraintRegexp = regexp.MustCompile(`^(=|!=|>=|<=|>|<|~>|~|\^)?v?((?:\d+|[xX*])(?:\.(?:\d+|[xX*]))*)(?:_(\d+))?(?:-([0-9A-Za-z]+(?:\.[0-9A-Za-z]+)*))?(?:\+(\d*))?(?:-[-.0-9A-Za-z]+)?$`)
+
+// constraints is a parsed range: any of its groups must accept a version,
+// and a group accepts it when all of its comparisons do.
+type constraints [][]*comparison
+
+type comparison struct {
+	op string
+	// numbers are the version numbers before the first wildcard, if any.
+	numbers  []uint64
+	wildcard bool
+	// legacy is set for "1.N" versions, which ~ bounds by N instead.
+	legacy     bool
+	prerelease string
+	build      uint64
+	hasBuild   bool
+}
+
+func parseConstraints(raw string) (constraints, error) {
+	var c constraints
+	for _, group := range strings.Split(raw, "||") {
+		tokens, err := comparisonTokens(group)
+		if err != nil {
+			return nil, err
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("%q is not a valid version", raw)
+		}
+		var comparisons []*comparison
+		for _, token := range tokens {
+			parsed, err := parseComparison(token)
+			if err != nil {
+				return nil, err
+			}
+			comparisons = append(comparisons, parsed...)
+		}
+		c = append(c, comparisons)
+	}
+	return c, nil
+}
+
+// comparisonTokens splits a group on commas and spaces, keeping operators
+// with their version (">= 1.2") and hyphen ranges ("1.2 - 1.4") together.
+func comparisonTokens(group string) ([]string, error) {
+	fields := strings.FieldsFunc(group, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	var tokens []string
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if strings.Trim(field, "=!<>~^") == "" {
+			if i+1 == len(fields) {
+				return nil, fmt.Errorf("%s is missing a version", field)
+			}
+			i++
+			field += fields[i]
+		}
+		if i+2 < len(fields) && fields[i+1] == "-" {
+			field += " - " + fields[i+2]
+			i += 2
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens, nil
+}
+
+func parseComparison(token string) ([]*comparison, error) {
+	if from, to, ok := strings.Cut(token, " - "); ok {
+		lower, err := parseComparison(">=" + from)
+		if err != nil {
+			return nil, err
+		}
+		upper, err := parseComparison("<=" + to)
+		if err != nil {
+			return nil, err
+		}
+		return append(lower, upper...), nil
+	}
+	m := constraintRegexp.FindStringSubmatch(token)
+	if m == nil {
+		return nil, fmt.Errorf("%s is not a valid version", token)
+	}
+	c := &comparison{op: m[1], prerelease: m[4]}
+	if c.op == "" {
+		c.op = "="
+	}
+	for _, s := range strings.Split(m[2], ".") {
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			c.wildcard = true
+			break
+		}
+		c.numbers = append(c.numbers, n)
+	}
+	if m[3] != "" && !c.wildcard {
+		update, err := strconv.ParseUint(m[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid version", token)
+		}
+		c.numbers = append(c.numbers, update)
+	}
+	c.numbers, c.legacy = normalizeLegacy(c.numbers, m[3] != "" && !c.wildcard)
+	if m[5] != "" {
+		build, err := strconv.ParseUint(m[5], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid version", token)
+		}
+		c.build, c.hasBuild = build, true
+	}
+	if b := legacyBuildRegexp.FindStringSubmatch(c.prerelease); b != nil && !c.hasBuild {
+		c.build, _ = strconv.ParseUint(b[1], 10, 64)
+		c.hasBuild = true
+		c.prerelease = ""
+	}
+	return []*comparison{c}, nil
+}
+
+func (c constraints) check(v *javaVersion) bool {
+	for _, group := range c {
+		if checkAll(group, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkAll(group []*comparison, v *javaVersion) bool {
+	for _, c := range group {
+		if !c.check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *comparison) check(v *javaVersion) bool {
+	// Pre-releases, such as early access builds, are only selected by
+	// comparisons that name a pre-release themselves.
+	if v.prerelease != "" && c.prerelease == "" {
+		return false
+	}
+	switch c.op {
+	case "=":
+		return c.matches(v)
+	case "!=":
+		return !c.matches(v)
+	case ">":
+		if c.wildcard {
+			return compareNumbers(v.numbers, c.next(len(c.numbers)-1)) >= 0
+		}
+		return c.compare(v) > 0
+	case ">=":
+		return c.compare(v) >= 0
+	case "<":
+		return c.compare(v) < 0
+	case "<=":
+		if c.wildcard {
+			return compareNumbers(v.numbers, c.next(len(c.numbers)-1)) < 0
+		}
+		return c.compare(v) <= 0
+	case "~", "~>":
+		// ~21.0.2 is >=21.0.2 <21.1, ~21 is >=21 <22 and ~1.8.144 is >=8.0.144 <9
+		i := min(len(c.numbers), 2) - 1
+		if c.legacy {
+			i = min(i, 0)
+		}
+		return c.compare(v) >= 0 && compareNumbers(v.numbers, c.next(i)) < 0
+	case "^":
+		// ^17.0.2 is >=17.0.2 <18, ^0.2.3 is >=0.2.3 <0.3
+		i := 0
+		for i < len(c.numbers)-1 && c.numbers[i] == 0 {
+			i++
+		}
+		return c.compare(v) >= 0 && compareNumbers(v.numbers, c.next(min(i, len(c.numbers)-1))) < 0
+	}
+	return false
+}
+
+// matches reports whether v starts with the numbers of c, so "21" and "21.x"
+// match every 21 release and "17.0.9" matches "17.0.9.1". Builds only count
+// when c names one.
+func (c *comparison) matches(v *javaVersion) bool {
+	if len(c.numbers) == 0 {
+		return true
+	}
+	for i, n := range c.numbers {
+		if v.number(i) != n {
+			return false
+		}
+	}
+	if comparePrerelease(v.prerelease, c.prerelease) != 0 {
+		return false
+	}
+	return !c.hasBuild || v.build == c.build
+}
+
+// compare compares v to the version of c, looking at build numbers only when
+// c names one.
+func (c *comparison) compare(v *javaVersion) int {
+	if r := compareNumbers(v.numbers, c.numbers); r != 0 {
+		return r
+	}
+	if r := comparePrerelease(v.prerelease, c.prerelease); r != 0 {
+		return r
+	}
+	if !c.hasBuild {
+		return 0
+	}
+	return cmp.Compare(v.build, c.build)
+}
+
+// next returns the smallest version numbers past every version starting with
+// the first i+1 numbers of c, such as 1.9 for i = 1 in 1.8.144.
+func (c *comparison) next(i int) []uint64 {
+	if i < 0 {
+		// "*" has no upper bound; nothing is past it.
+		return []uint64{^uint64(0)}
+	}
+	next := append([]uint64(nil), c.numbers[:i+1]...)
+	next[i]++
+	return next
+}
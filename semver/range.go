@@ -1,11 +1,13 @@
 package semver
 
 import (
+	"cmp"
 	"fmt"
+	"path"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
-
-	"github.com/Masterminds/semver/v3"
 )
 
 var pre070CompatRegexp = regexp.MustCompile("(^|,\\s*)\\d+([.]\\d+)?[.]?")
@@ -36,14 +38,173 @@ func pre070Compat(version string) string {
 	return strings.Join(split, " ")
 }
 
+// singleMajorRegexp matches selectors made of one version, optionally with
+// a tilde or caret, such as "21", "21.x", "~17.0.2" or "^11".
+var singleMajorRegexp = regexp.MustCompile(`^\s*([~^]?)\s*v?(\d+)((?:\.(?:\d+|[xX*]))*)([-+_]\S*)?\s*$`)
+
+// nthLTSRegexp matches "N-lts", the Nth newest LTS release ("1-lts" is the
+// newest).
+var nthLTSRegexp = regexp.MustCompile(`^([1-9]\d{0,2})-lts$`)
+
+// distPrefix introduces selectors for vendor versions, e.g. "zulu@dist:21.32.17".
+const distPrefix = "dist:"
+
+var distVersionRegexp = regexp.MustCompile(`^\d+(?:[._+-]\d+)*$`)
+
+// Symbolic selectors, resolved against the available releases with Resolve.
+const (
+	SymbolLatest      = "latest"
+	SymbolStable      = "stable"
+	SymbolLTS         = "lts"
+	SymbolLatestLTS   = "latest-lts"
+	SymbolPreviousLTS = "previous-lts"
+)
+
 type Range struct {
-	Qualifier string
-	raw       string
-	rng       *semver.Constraints
+	Qualifier  string
+	raw        string
+	rng        constraints
+	major      uint64
+	hasMajor   bool
+	wholeMajor bool
+	symbol     string
+	ltsRank    int
+	// distVersion holds the components of a "dist:" selector.
+	distVersion []string
+}
+
+// MajorRelease describes a feature release, such as 21, that a symbolic
+// range may resolve to.
+type MajorRelease struct {
+	Major uint64
+	LTS   bool
 }
 
+// Contains reports whether v is in the range. A symbolic range contains no
+// version until it is resolved.
 func (r *Range) Contains(v *Version) bool {
-	return (r.Qualifier == v.qualifier || r.Qualifier == "*" || r.Qualifier == "") && r.rng.Check(v.ver)
+	return r.MatchesQualifier(v) && r.rng != nil && r.rng.check(v.ver)
+}
+
+// MatchesQualifier reports whether v belongs to the distribution the range
+// selects, ignoring the version constraint.
+func (r *Range) MatchesQualifier(v *Version) bool {
+	return r.QualifierRank(v) >= 0
+}
+
+// Qualifiers returns the distributions the range selects, most preferred
+// first: "temurin|zulu@21" selects temurin, then zulu. Each may be a glob
+// pattern such as "graalvm*".
+func (r *Range) Qualifiers() []string {
+	if r.Qualifier == "" {
+		return nil
+	}
+	return strings.Split(r.Qualifier, "|")
+}
+
+// QualifierRank returns the position among Qualifiers of the first one that
+// v belongs to, or -1 when there is none. Every version ranks 0 in ranges
+// without a qualifier.
+func (r *Range) QualifierRank(v *Version) int {
+	if r.Qualifier == "" {
+		return 0
+	}
+	for i, q := range r.Qualifiers() {
+		if MatchesQualifierPattern(q, v.qualifier) {
+			return i
+		}
+	}
+	return -1
+}
+
+// MatchesQualifierPattern reports whether qualifier is selected by pattern,
+// which is either a name or a glob pattern. "*" selects any qualifier.
+func MatchesQualifierPattern(pattern, qualifier string) bool {
+	if pattern == "*" || pattern == qualifier {
+		return true
+	}
+	if !IsQualifierPattern(pattern) {
+		return false
+	}
+	matched, _ := path.Match(pattern, qualifier)
+	return matched
+}
+
+// IsQualifierPattern reports whether qualifier is a glob pattern.
+func IsQualifierPattern(qualifier string) bool {
+	return strings.ContainsAny(qualifier, "*?[")
+}
+
+// WithQualifier returns a copy of the range selecting qualifier instead of
+// the distribution it was parsed with.
+func (r *Range) WithQualifier(qualifier string) *Range {
+	c := *r
+	c.Qualifier = qualifier
+	_, version, found := strings.Cut(r.raw, "@")
+	if !found {
+		version = r.raw
+	}
+	c.raw = qualifier + "@" + version
+	return &c
+}
+
+// DistributionVersion reports whether the range is a "dist:" selector, which
+// matches the vendor's own version number instead of the Java version.
+func (r *Range) DistributionVersion() (string, bool) {
+	return strings.Join(r.distVersion, "."), r.distVersion != nil
+}
+
+// MatchesDistributionVersion reports whether version, a vendor version such as
+// "21.32.17" or "21.0.4.7.1", starts with the components of a "dist:"
+// selector. "dist:21.32" therefore matches "21.32.17".
+func (r *Range) MatchesDistributionVersion(version string) bool {
+	if r.distVersion == nil {
+		return false
+	}
+	components := distVersionComponents(version)
+	return len(components) >= len(r.distVersion) && slices.Equal(components[:len(r.distVersion)], r.distVersion)
+}
+
+// Symbol returns the symbolic selector of the range, such as "lts", or an
+// empty string for ranges made of versions.
+func (r *Range) Symbol() string {
+	return r.symbol
+}
+
+// Resolve turns a symbolic range into the range of the major release it
+// selects among releases. Ranges that are not symbolic are returned as is.
+func (r *Range) Resolve(releases []MajorRelease) (*Range, error) {
+	if r.symbol == "" {
+		return r, nil
+	}
+	var majors []uint64
+	for _, release := range releases {
+		if (r.ltsRank == 0 || release.LTS) && !slices.Contains(majors, release.Major) {
+			majors = append(majors, release.Major)
+		}
+	}
+	slices.SortFunc(majors, func(a, b uint64) int { return cmp.Compare(b, a) })
+	index := max(r.ltsRank-1, 0)
+	if index >= len(majors) {
+		return nil, fmt.Errorf("no release matches %s", r.raw)
+	}
+	selector := strconv.FormatUint(majors[index], 10)
+	if r.Qualifier != "" {
+		selector = r.Qualifier + "@" + selector
+	}
+	return ParseRange(selector)
+}
+
+// Major returns the major version shared by every version in the range. ok is
+// false when the range spans several major versions or cannot tell.
+func (r *Range) Major() (major uint64, ok bool) {
+	return r.major, r.hasMajor
+}
+
+// CoversMajor reports whether the range accepts every release of its major
+// version, as "21" or "^21" do, so that the newest release always matches.
+func (r *Range) CoversMajor() bool {
+	return r.wholeMajor
 }
 
 func (r *Range) String() string {
@@ -57,16 +218,77 @@ func ParseRange(raw string) (*Range, error) {
 	if strings.Contains(raw, "@") {
 		p.Qualifier = raw[0:strings.Index(raw, "@")]
 		raw = raw[strings.Index(raw, "@")+1:]
+		for _, q := range p.Qualifiers() {
+			if _, err := path.Match(q, ""); q == "" || err != nil {
+				return nil, fmt.Errorf("%s is not a valid distribution", p.raw)
+			}
+		}
 		if raw == "" {
 			// `jabba ls-remote zulu@` convenience
 			raw = ">=0.0.0-0"
 		}
 	}
+	if dist, ok := strings.CutPrefix(strings.TrimSpace(raw), distPrefix); ok {
+		if !distVersionRegexp.MatchString(dist) {
+			return nil, fmt.Errorf("%s is not a valid distribution version", p.raw)
+		}
+		p.distVersion = distVersionComponents(dist)
+		return p, nil
+	}
+	if symbol := strings.ToLower(strings.TrimSpace(raw)); isSymbol(symbol) {
+		p.symbol = symbol
+		p.ltsRank = ltsRank(symbol)
+		return p, nil
+	}
 	constraint := pre070Compat(raw)
-	parsed, err := semver.NewConstraint(constraint)
+	parsed, err := parseConstraints(constraint)
 	if err != nil {
 		return nil, fmt.Errorf("%s is not a valid version", p.raw)
 	}
 	p.rng = parsed
+	if m := singleMajorRegexp.FindStringSubmatch(raw); m != nil {
+		parts := strings.Split(m[2]+m[3], ".")
+		if len(parts) >= 2 && parts[0] == "1" && len(parts[1]) == 1 && parts[1] >= "5" && parts[1] <= "9" {
+			// 1.8 -> 8
+			parts = parts[1:]
+		}
+		if major, err := strconv.ParseUint(parts[0], 10, 64); err == nil {
+			p.major = major
+			p.hasMajor = true
+			// "^17.0.2" and "17.0.2" exclude older releases of 17.
+			p.wholeMajor = m[4] == "" && strings.Trim(strings.Join(parts[1:], ""), "xX*") == ""
+		}
+	}
 	return p, nil
 }
+
+func isSymbol(s string) bool {
+	switch s {
+	case SymbolLatest, SymbolStable, SymbolLTS, SymbolLatestLTS, SymbolPreviousLTS:
+		return true
+	}
+	return nthLTSRegexp.MatchString(s)
+}
+
+// ltsRank returns which LTS release, counting from the newest, a symbol
+// selects, or 0 when it selects among all releases.
+func ltsRank(symbol string) int {
+	switch symbol {
+	case SymbolLatest, SymbolStable:
+		return 0
+	case SymbolLTS, SymbolLatestLTS:
+		return 1
+	case SymbolPreviousLTS:
+		return 2
+	}
+	n, _ := strconv.Atoi(nthLTSRegexp.FindStringSubmatch(symbol)[1])
+	return n
+}
+
+// distVersionComponents splits a vendor version on the separators vendors
+// use, so "21.32+17" and "21.32.17" compare equal.
+func distVersionComponents(version string) []string {
+	return strings.FieldsFunc(version, func(r rune) bool {
+		return r == '.' || r == '+' || r == '_' || r == '-'
+	})
+}
@@ -42,3 +42,215 @@ func assertWithinRange(t *testing.T, rng string, ver string, value bool) {
 		t.Fatalf("expected range %v to contain %v (%v)", rng, ver, value)
 	}
 }
+
+func TestRangeMajor(t *testing.T) {
+	tests := []struct {
+		rng         string
+		major       uint64
+		ok          bool
+		coversMajor bool
+	}{
+		{"21", 21, true, true},
+		{"temurin@21.x", 21, true, true},
+		{"^17", 17, true, true},
+		{"~17.0.2", 17, true, false},
+		{"zulu@17.0.2", 17, true, false},
+		{"1.8", 8, true, true},
+		{"1.8.0_392", 8, true, false},
+		{">=21 <22", 0, false, false},
+		{"zulu@", 0, false, false},
+		{"11 || 17", 0, false, false},
+	}
+	for _, tt := range tests {
+		r, err := ParseRange(tt.rng)
+		if err != nil {
+			t.Fatalf("ParseRange(%q): %v", tt.rng, err)
+		}
+		major, ok := r.Major()
+		if major != tt.major || ok != tt.ok || r.CoversMajor() != tt.coversMajor {
+			t.Errorf("%q: Major() = %d, %v; CoversMajor() = %v; want %d, %v, %v",
+				tt.rng, major, ok, r.CoversMajor(), tt.major, tt.ok, tt.coversMajor)
+		}
+	}
+}
+
+func TestResolveSymbolicRange(t *testing.T) {
+	releases := []MajorRelease{{25, true}, {24, false}, {21, true}, {17, true}, {21, true}, {11, true}}
+	tests := []struct {
+		rng  string
+		want string
+	}{
+		{"latest", "25"},
+		{"zulu@stable", "zulu@25"},
+		{"temurin@lts", "temurin@25"},
+		{"LATEST-LTS", "25"},
+		{"previous-lts", "21"},
+		{"3-lts", "17"},
+		{"21", "21"},
+	}
+	for _, tt := range tests {
+		r, err := ParseRange(tt.rng)
+		if err != nil {
+			t.Fatalf("ParseRange(%q): %v", tt.rng, err)
+		}
+		resolved, err := r.Resolve(releases)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.rng, err)
+		}
+		if resolved.String() != tt.want {
+			t.Errorf("%q resolved to %q, want %q", tt.rng, resolved, tt.want)
+		}
+	}
+
+	r, err := ParseRange("5-lts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Resolve(releases); err == nil {
+		t.Errorf("expected an error when there are not enough LTS releases")
+	}
+	if r.Contains(&Version{}) {
+		t.Errorf("an unresolved symbolic range must not contain versions")
+	}
+}
+
+func TestRangeWithQualifier(t *testing.T) {
+	r, err := ParseRange("adoptium@~21.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := r.WithQualifier("temurin")
+	if c.String() != "temurin@~21.0.1" || r.String() != "adoptium@~21.0.1" {
+		t.Fatalf("WithQualifier gave %q, original %q", c, r)
+	}
+	assertContains := func(rng *Range, ver string, want bool) {
+		t.Helper()
+		v, err := ParseVersion(ver)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rng.Contains(v) != want {
+			t.Errorf("%s contains %s = %v, want %v", rng, ver, !want, want)
+		}
+	}
+	assertContains(c, "temurin@21.0.2", true)
+	assertContains(r, "temurin@21.0.2", false)
+}
+
+func TestDistributionVersionRange(t *testing.T) {
+	r, err := ParseRange("zulu@dist:21.32")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version, ok := r.DistributionVersion(); !ok || version != "21.32" || r.Qualifier != "zulu" {
+		t.Fatalf("DistributionVersion() = %q, %v; qualifier %q", version, ok, r.Qualifier)
+	}
+	for version, want := range map[string]bool{"21.32.17": true, "21.32+17": true, "21.32": true, "21.3.2": false, "21.320.1": false, "21": false} {
+		if got := r.MatchesDistributionVersion(version); got != want {
+			t.Errorf("MatchesDistributionVersion(%q) = %v, want %v", version, got, want)
+		}
+	}
+	if c := r.WithQualifier("azul"); c.String() != "azul@dist:21.32" || !c.MatchesDistributionVersion("21.32.17") {
+		t.Errorf("WithQualifier lost the distribution version: %q", c)
+	}
+	if _, err := ParseRange("zulu@dist:latest"); err == nil {
+		t.Errorf("expected an invalid distribution version to be rejected")
+	}
+	plain, _ := ParseRange("21")
+	if _, ok := plain.DistributionVersion(); ok || plain.MatchesDistributionVersion("21") {
+		t.Errorf("plain ranges must not match distribution versions")
+	}
+}
+
+func TestJavaVersionRanges(t *testing.T) {
+	assertWithinRange(t, ">=21.0.2+13", "21.0.2+13", true)
+	assertWithinRange(t, ">=21.0.2+13", "21.0.2+12", false)
+	assertWithinRange(t, ">=21.0.2+13", "21.0.3+1", true)
+	assertWithinRange(t, "21.0.2+13", "21.0.2+13-LTS", true)
+	assertWithinRange(t, "21.0.2+13", "21.0.2+12", false)
+	assertWithinRange(t, "21.0.2", "21.0.2+12", true)
+	assertWithinRange(t, ">=21.0.2", "21.0.2+1", true)
+	assertWithinRange(t, "<21.0.2", "21.0.2+1", false)
+	assertWithinRange(t, "17.0.9", "17.0.9.1", true)
+	assertWithinRange(t, "~17.0.9", "17.0.9.1", true)
+	assertWithinRange(t, "21", "21.0.4+7", true)
+	assertWithinRange(t, "21", "22-ea+27", false)
+	assertWithinRange(t, "21", "21-ea+5", false)
+	assertWithinRange(t, "zulu@", "zulu@22-ea+27", true)
+	assertWithinRange(t, "^17", "17.0.12+7", true)
+	assertWithinRange(t, "^17", "18", false)
+	assertWithinRange(t, "11 || 17", "17.0.1", true)
+	assertWithinRange(t, "11 || 17", "21.0.1", false)
+	assertWithinRange(t, "1.8", "1.8.0_392-b08", true)
+	assertWithinRange(t, ">=1.8.0_300", "1.8.0_392-b08", true)
+	assertWithinRange(t, "17 - 21.x", "21.0.4", true)
+	assertWithinRange(t, "17 - 21", "17.0.1", true)
+	assertWithinRange(t, "17 - 21", "22", false)
+	assertWithinRange(t, "!=21", "21.0.4", false)
+	assertWithinRange(t, "*", "25", true)
+
+	for _, invalid := range []string{"21.0.2+b13", ">=", "21 ||", "foo"} {
+		if _, err := ParseRange(invalid); err == nil {
+			t.Errorf("ParseRange(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestLegacyVersionsMatchFeatureReleases(t *testing.T) {
+	assertWithinRange(t, "8", "1.8.0_392", true)
+	assertWithinRange(t, "1.8", "8.0.392+8", true)
+	assertWithinRange(t, "temurin@8.0.392", "temurin@1.8.0_392-b08", true)
+	assertWithinRange(t, "temurin@1.8.0_392", "temurin@8.0.392+8", true)
+	assertWithinRange(t, "~1.8.300", "8.0.392", true)
+	assertWithinRange(t, "~1.8.400", "8.0.392", false)
+	assertWithinRange(t, "zulu@1.8.392", "zulu@8.0.392+8", true)
+	assertWithinRange(t, "1.8.0", "8.0.392", true)
+	assertWithinRange(t, ">=1.8 <11", "8.0.392", true)
+	assertWithinRange(t, "1.8", "11.0.1", false)
+	assertWithinRange(t, "1.4", "1.4.2_19", true)
+}
+
+func TestQualifierAlternativesAndPatterns(t *testing.T) {
+	assertWithinRange(t, "temurin|zulu@21", "zulu@21.0.4", true)
+	assertWithinRange(t, "temurin|zulu@21", "temurin@21.0.4", true)
+	assertWithinRange(t, "temurin|zulu@21", "corretto@21.0.4", false)
+	assertWithinRange(t, "temurin|zulu@21", "zulu@17.0.4", false)
+	assertWithinRange(t, "graalvm*@21", "graalvm_community@21.0.2", true)
+	assertWithinRange(t, "graalvm*@21", "temurin@21.0.2", false)
+	assertWithinRange(t, "temurin|graalvm_c?@21", "graalvm_ce@21.0.2", true)
+
+	r, err := ParseRange("corretto|graalvm*|temurin@21")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for ver, want := range map[string]int{"corretto@21": 0, "graalvm_ce17@21": 1, "temurin@21": 2, "zulu@21": -1, "21": -1} {
+		v, err := ParseVersion(ver)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := r.QualifierRank(v); got != want {
+			t.Errorf("QualifierRank(%s) = %d, want %d", ver, got, want)
+		}
+	}
+	if q := r.Qualifiers(); len(q) != 3 || q[1] != "graalvm*" {
+		t.Errorf("Qualifiers() = %v", q)
+	}
+	if resolved, err := mustParseRange(t, "temurin|zulu@lts").Resolve([]MajorRelease{{21, true}}); err != nil || resolved.String() != "temurin|zulu@21" {
+		t.Errorf("Resolve() = %v, %v", resolved, err)
+	}
+
+	for _, invalid := range []string{"temurin|@21", "|zulu@21", "graalvm[@21"} {
+		if _, err := ParseRange(invalid); err == nil {
+			t.Errorf("ParseRange(%q) should fail", invalid)
+		}
+	}
+}
+
+func mustParseRange(t *testing.T, raw string) *Range {
+	t.Helper()
+	r, err := ParseRange(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
@@ -1,26 +1,190 @@
 package semver
 
 import (
+	"cmp"
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
-
-	"github.com/Masterminds/semver/v3"
 )
 
+// versionRegexp matches JEP 223/322 version strings, $VNUM(-$PRE)?(+$BUILD)?(-$OPT)?,
+// where the version numbers may be followed by a legacy "_$UPDATE" as in
+// "1.8.0_392". Wildcards are only accepted in ranges.
+var versionRegexp = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)(?:_(\d+))?(?:-([0-9A-Za-z]+(?:\.[0-9A-Za-z]+)*))?(?:\+(\d*))?(?:-([-.0-9A-Za-z]+))?$`)
+
+// legacyBuildRegexp matches the "-bNN" build suffix of pre-JEP 223 releases,
+// which would otherwise be read as a pre-release.
+var legacyBuildRegexp = regexp.MustCompile(`^b(\d+)$`)
+
 type Version struct {
 	qualifier string
 	raw       string
-	ver       *semver.Version
+	ver       *javaVersion
+}
+
+// javaVersion is a parsed Java version. Versions compare by their numbers,
+// trailing zeros aside, then by pre-release, which sorts before the release,
+// and finally by build number. The optional information never counts.
+type javaVersion struct {
+	numbers    []uint64
+	prerelease string
+	build      uint64
+	hasBuild   bool
+	optional   string
+}
+
+func parseJavaVersion(raw string) (*javaVersion, error) {
+	m := versionRegexp.FindStringSubmatch(raw)
+	if m == nil {
+		return nil, fmt.Errorf("%s is not a valid version", raw)
+	}
+	v := &javaVersion{prerelease: m[3], optional: m[5]}
+	for _, s := range strings.Split(m[1], ".") {
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid version", raw)
+		}
+		v.numbers = append(v.numbers, n)
+	}
+	if m[2] != "" {
+		update, err := strconv.ParseUint(m[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid version", raw)
+		}
+		v.numbers = append(v.numbers, update)
+	}
+	if m[4] != "" {
+		build, err := strconv.ParseUint(m[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid version", raw)
+		}
+		v.build, v.hasBuild = build, true
+	}
+	v.numbers, _ = normalizeLegacy(v.numbers, m[2] != "")
+	if b := legacyBuildRegexp.FindStringSubmatch(v.prerelease); b != nil && !v.hasBuild {
+		// 1.8.0_392-b08
+		v.build, _ = strconv.ParseUint(b[1], 10, 64)
+		v.hasBuild = true
+		v.prerelease = ""
+	}
+	return v, nil
+}
+
+// normalizeLegacy drops the "1." of the scheme used up to Java 8, so that
+// 1.8.0_392 reads as 8.0.392, the way DiscoAPI and JEP 223 number it. jabba's
+// 1.8.392 form, without an "_update", reads the same. Only 1.5 to 1.9 are
+// rewritten, as older releases never had a feature number of their own.
+func normalizeLegacy(numbers []uint64, hasUpdate bool) ([]uint64, bool) {
+	if len(numbers) < 2 || numbers[0] != 1 || numbers[1] < 5 || numbers[1] > 9 {
+		return numbers, false
+	}
+	numbers = numbers[1:]
+	if len(numbers) == 2 && !hasUpdate && numbers[1] != 0 {
+		numbers = []uint64{numbers[0], 0, numbers[1]}
+	}
+	return numbers, true
+}
+
+func (v *javaVersion) number(i int) uint64 {
+	if i < len(v.numbers) {
+		return v.numbers[i]
+	}
+	return 0
+}
+
+// compareRelease compares v and other ignoring build numbers.
+func (v *javaVersion) compareRelease(other *javaVersion) int {
+	if c := compareNumbers(v.numbers, other.numbers); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.prerelease, other.prerelease)
+}
+
+func (v *javaVersion) compare(other *javaVersion) int {
+	if c := v.compareRelease(other); c != 0 {
+		return c
+	}
+	return cmp.Compare(v.build, other.build)
+}
+
+// compareNumbers compares version numbers as if the shorter one was padded
+// with zeros, so 21 and 21.0.0 are the same.
+func compareNumbers(a, b []uint64) int {
+	for i := range max(len(a), len(b)) {
+		var x, y uint64
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if c := cmp.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// comparePrerelease orders pre-releases before the release itself, and
+// pre-releases by their dot-separated identifiers, numbers numerically.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := range min(len(as), len(bs)) {
+		x, xerr := strconv.ParseUint(as[i], 10, 64)
+		y, yerr := strconv.ParseUint(bs[i], 10, 64)
+		var c int
+		switch {
+		case xerr == nil && yerr == nil:
+			c = cmp.Compare(x, y)
+		case xerr == nil:
+			c = -1
+		case yerr == nil:
+			c = 1
+		default:
+			c = strings.Compare(as[i], bs[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(as), len(bs))
 }
 
 func (v *Version) LessThan(other *Version) bool {
 	if v.qualifier == other.qualifier {
-		return v.ver.LessThan(other.ver)
+		return v.ver.compare(other.ver) < 0
 	}
 	return v.qualifier > other.qualifier
 }
 
+// Compare returns -1, 0 or +1 depending on whether v is older than, the same
+// as or newer than other. Build numbers break ties, and qualifiers are ignored.
+func (v *Version) Compare(other *Version) int {
+	return v.ver.compare(other.ver)
+}
+
+// CompareRelease is like Compare, but ignores build numbers, so "21.0.2" and
+// "21.0.2+13" are the same release.
+func (v *Version) CompareRelease(other *Version) int {
+	return v.ver.compareRelease(other.ver)
+}
+
+// Qualifier returns the distribution part of the version, such as "temurin"
+// in "temurin@21.0.2".
+func (v *Version) Qualifier() string {
+	return v.qualifier
+}
+
 func (v *Version) Equals(other *Version) bool {
 	return v.raw == other.raw
 }
@@ -29,6 +193,24 @@ func (v *Version) String() string {
 	return v.raw
 }
 
+// Release returns v without its build number and optional information, such
+// as "temurin@21.0.2" for "temurin@21.0.2+13-LTS".
+func (v *Version) Release() string {
+	s := v.qualifier
+	if s != "" {
+		s += "@"
+	}
+	numbers := make([]string, len(v.ver.numbers))
+	for i, n := range v.ver.numbers {
+		numbers[i] = strconv.FormatUint(n, 10)
+	}
+	s += strings.Join(numbers, ".")
+	if v.ver.prerelease != "" {
+		s += "-" + v.ver.prerelease
+	}
+	return s
+}
+
 func (v *Version) TrimTo(part VersionPart) string {
 	prefix := v.qualifier
 	if prefix != "" {
@@ -36,29 +218,41 @@ func (v *Version) TrimTo(part VersionPart) string {
 	}
 	switch part {
 	case VPMajor:
-		return fmt.Sprintf("%v%v", prefix, v.ver.Major())
+		return fmt.Sprintf("%v%v", prefix, v.Major())
 	case VPMinor:
-		return fmt.Sprintf("%v%v.%v", prefix, v.ver.Major(), v.ver.Minor())
+		return fmt.Sprintf("%v%v.%v", prefix, v.Major(), v.Minor())
 	case VPPatch:
-		return fmt.Sprintf("%v%v.%v.%v", prefix, v.ver.Major(), v.ver.Minor(), v.ver.Patch())
+		return fmt.Sprintf("%v%v.%v.%v", prefix, v.Major(), v.Minor(), v.Patch())
 	}
 	return v.raw
 }
 
 func (v *Version) Major() uint64 {
-	return v.ver.Major()
+	return v.ver.number(0)
 }
 
 func (v *Version) Minor() uint64 {
-	return v.ver.Minor()
+	return v.ver.number(1)
 }
 
 func (v *Version) Patch() uint64 {
-	return v.ver.Patch()
+	return v.ver.number(2)
 }
 
 func (v *Version) Prerelease() string {
-	return v.ver.Prerelease()
+	return v.ver.prerelease
+}
+
+// Build returns the build number, as in "21.0.2+13". ok is false when the
+// version has none.
+func (v *Version) Build() (build uint64, ok bool) {
+	return v.ver.build, v.ver.hasBuild
+}
+
+// Optional returns the optional information after the build, such as "LTS"
+// in "21.0.2+13-LTS".
+func (v *Version) Optional() string {
+	return v.ver.optional
 }
 
 func ParseVersion(raw string) (*Version, error) {
@@ -69,9 +263,9 @@ func ParseVersion(raw string) (*Version, error) {
 		p.qualifier = raw[0:strings.Index(raw, "@")]
 		raw = raw[strings.Index(raw, "@")+1:]
 	}
-	parsed, err := semver.NewVersion(raw)
+	parsed, err := parseJavaVersion(raw)
 	if err != nil {
-		return nil, fmt.Errorf("%s is not a valid version", raw)
+		return nil, err
 	}
 	p.ver = parsed
 	return p, nil
@@ -103,7 +297,7 @@ func (s VersionSlice) TrimTo(part VersionPart) VersionSlice {
 	latest := make(map[string]*Version)
 	for _, v := range s {
 		key := versionTrimKey(v, part)
-		if prev, ok := latest[key]; !ok || v.ver.GreaterThan(prev.ver) {
+		if prev, ok := latest[key]; !ok || v.ver.compare(prev.ver) > 0 {
 			latest[key] = v
 		}
 	}
@@ -392,3 +392,73 @@ func asVersionSlice(t *testing.T, slice ...string) (r VersionSlice) {
 	}
 	return
 }
+
+func TestParseJavaVersions(t *testing.T) {
+	tests := []struct {
+		input      string
+		numbers    []uint64
+		prerelease string
+		build      uint64
+		hasBuild   bool
+		optional   string
+	}{
+		{"21", []uint64{21}, "", 0, false, ""},
+		{"21.0.2+13", []uint64{21, 0, 2}, "", 13, true, ""},
+		{"21.0.2+13-LTS", []uint64{21, 0, 2}, "", 13, true, "LTS"},
+		{"17.0.9.1", []uint64{17, 0, 9, 1}, "", 0, false, ""},
+		{"22-ea+27", []uint64{22}, "ea", 27, true, ""},
+		{"1.8.0_392", []uint64{8, 0, 392}, "", 0, false, ""},
+		{"1.8.0_392-b08", []uint64{8, 0, 392}, "", 8, true, ""},
+		{"1.8.0-b132", []uint64{8, 0}, "", 132, true, ""},
+		{"1.8.392", []uint64{8, 0, 392}, "", 0, false, ""},
+		{"1.4.2_19", []uint64{1, 4, 2, 19}, "", 0, false, ""},
+		{"temurin@8.0.392+8", []uint64{8, 0, 392}, "", 8, true, ""},
+	}
+	for _, tt := range tests {
+		v, err := ParseVersion(tt.input)
+		if err != nil {
+			t.Errorf("ParseVersion(%q): %v", tt.input, err)
+			continue
+		}
+		build, hasBuild := v.Build()
+		if !reflect.DeepEqual(v.ver.numbers, tt.numbers) || v.Prerelease() != tt.prerelease ||
+			build != tt.build || hasBuild != tt.hasBuild || v.Optional() != tt.optional {
+			t.Errorf("ParseVersion(%q) = %+v, want numbers %v, pre %q, build %d (%v), opt %q",
+				tt.input, *v.ver, tt.numbers, tt.prerelease, tt.build, tt.hasBuild, tt.optional)
+		}
+		if v.String() != tt.input {
+			t.Errorf("String() = %q, want %q", v.String(), tt.input)
+		}
+	}
+	for _, input := range []string{"", "21.", "21..1", "21.0.2+b13", "1.8.0_"} {
+		if _, err := ParseVersion(input); err == nil {
+			t.Errorf("ParseVersion(%q) should fail", input)
+		}
+	}
+}
+
+func TestCompareJavaVersions(t *testing.T) {
+	ordered := []string{"21-ea+5", "21.0.2", "21.0.2+12", "21.0.2+13", "21.0.2.1", "21.0.10", "22-ea+27", "22"}
+	for i := 1; i < len(ordered); i++ {
+		older, _ := ParseVersion(ordered[i-1])
+		newer, _ := ParseVersion(ordered[i])
+		if older.Compare(newer) >= 0 || newer.Compare(older) <= 0 {
+			t.Errorf("expected %s < %s", older, newer)
+		}
+	}
+	a, _ := ParseVersion("21.0.2+13-LTS")
+	b, _ := ParseVersion("21.0.2+13")
+	c, _ := ParseVersion("21.0.2")
+	if a.Compare(b) != 0 {
+		t.Errorf("optional information must not count: %s vs %s", a, b)
+	}
+	if a.CompareRelease(c) != 0 || a.Compare(c) <= 0 {
+		t.Errorf("builds must only break ties: %s vs %s", a, c)
+	}
+	if a.Release() != "21.0.2" {
+		t.Errorf("Release() = %q", a.Release())
+	}
+	if d, _ := ParseVersion("21.0"); d.Compare(c) >= 0 || d.Compare(&Version{ver: &javaVersion{numbers: []uint64{21, 0, 0}}}) != 0 {
+		t.Errorf("trailing zeros must not count")
+	}
+}